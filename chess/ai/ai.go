@@ -1,36 +1,46 @@
 package ai
 
 import (
+  "context"
   "fmt"
   "jsdu/chess/game"
   "minimax"
+  "time"
 )
 
-type AiGame struct {
+// Searched with *game.Move moves, so they don't need converting or type
+// assertions.
+type TypedAiGame struct {
   chessGame *game.Game
 }
 
-func MakeAiGame(chessGame *game.Game) *AiGame {
-  return &AiGame{chessGame}
+func MakeTypedAiGame(chessGame *game.Game) *TypedAiGame {
+  return &TypedAiGame{chessGame}
 }
 
-func (aiGame *AiGame) String() string {
+func (aiGame *TypedAiGame) String() string {
   return aiGame.chessGame.String()
 }
 
-func (aiGame *AiGame) GetAllMoves() []minimax.MiniMaxMove {
-  chessMoves := aiGame.chessGame.GetAllMoves()
-  moves := make([]minimax.MiniMaxMove, 0, len(chessMoves))
-  for _, chessMove := range chessMoves {
-    moves = append(moves, chessMove)
-    if !chessMove.InRange() {
-      fmt.Printf("wtf:\n%v\nmove: %v\n", aiGame.chessGame, chessMove)
+func (aiGame *TypedAiGame) GetAllMoves() []*game.Move {
+  return aiGame.chessGame.GetAllMoves()
+}
+
+// Captures, including en passant, and promotions.
+func (aiGame *TypedAiGame) GetNoisyMoves() []*game.Move {
+  board := aiGame.chessGame.GetBoard()
+  moves := make([]*game.Move, 0, 8)
+  for _, move := range aiGame.chessGame.GetAllMoves() {
+    from, to := move.From(), move.To()
+    enPassant := board.Get(from).GetName() == 'p' && from.Col() != to.Col()
+    if board.Get(to) != nil || enPassant || move.PromoteTo() != 0 {
+      moves = append(moves, move)
     }
   }
   return moves
 }
 
-func (aiGame *AiGame) GetScore() minimax.Score {
+func (aiGame *TypedAiGame) GetScore() minimax.Score {
   /*
   state := aiGame.chessGame.GetState()
   switch state {
@@ -44,38 +54,225 @@ func (aiGame *AiGame) GetScore() minimax.Score {
       board.GetPoints(game.White) - board.GetPoints(game.Black))
 }
 
-func (aiGame *AiGame) MakeMove(move minimax.MiniMaxMove) {
-  ok := aiGame.chessGame.MakeMove(move.(*game.Move))
+func (aiGame *TypedAiGame) MakeMove(move *game.Move) {
+  ok := aiGame.chessGame.MakeMove(move)
   if !ok {
     panic(aiGame)
   }
 }
 
-func (aiGame *AiGame) UndoMove() {
+func (aiGame *TypedAiGame) UndoMove() {
   if ok := aiGame.chessGame.UndoMove(); !ok {
     panic(aiGame)
   }
 }
 
-func (aiGame *AiGame) StringKey() string {
+func (aiGame *TypedAiGame) MakeNullMove() {
+  aiGame.chessGame.MakeNullMove()
+}
+
+func (aiGame *TypedAiGame) UndoNullMove() {
+  if ok := aiGame.chessGame.UndoNullMove(); !ok {
+    panic(aiGame)
+  }
+}
+
+// Passing is illegal in check, and with only pawns left zugzwang is common.
+func (aiGame *TypedAiGame) CanMakeNullMove() bool {
+  if aiGame.chessGame.InCheck() {
+    return false
+  }
+  board := aiGame.chessGame.GetBoard()
+  for _, piece := range board.GetPieces(aiGame.chessGame.Turn()) {
+    if name := piece.GetName(); name != 'p' && name != 'k' {
+      return true
+    }
+  }
+  return false
+}
+
+func (aiGame *TypedAiGame) StringKey() string {
   return aiGame.chessGame.GetBoard().StringKey()
 }
 
+// Searches captures first, most valuable victim then least valuable attacker,
+// and promotions along with them.
+func (aiGame *TypedAiGame) MovePriority(move *game.Move) int {
+  board := aiGame.chessGame.GetBoard()
+  priority := 0
+  if victim := board.Get(move.To()); victim != nil {
+    priority += 10 * victim.GetPoints() - board.Get(move.From()).GetPoints()
+    // Even a pawn taking a pawn is worth trying early.
+    priority += 10
+  }
+  if promoteTo := move.PromoteTo(); promoteTo != 0 {
+    priority += 10 * game.MakePiece(promoteTo, game.White).GetPoints()
+  }
+  return priority
+}
+
+func (aiGame *TypedAiGame) Clone() minimax.Game[*game.Move] {
+  return &TypedAiGame{aiGame.chessGame.Clone()}
+}
+
+func (aiGame *TypedAiGame) Hash() uint64 {
+  return aiGame.chessGame.Hash()
+}
+
+// Adapts TypedAiGame to MiniMaxGame, for searches that need one.
+type AiGame struct {
+  TypedAiGame
+}
+
+func MakeAiGame(chessGame *game.Game) *AiGame {
+  return &AiGame{TypedAiGame{chessGame}}
+}
+
+func (aiGame *AiGame) GetAllMoves() []minimax.MiniMaxMove {
+  chessMoves := aiGame.chessGame.GetAllMoves()
+  moves := make([]minimax.MiniMaxMove, 0, len(chessMoves))
+  for _, chessMove := range chessMoves {
+    moves = append(moves, chessMove)
+    if !chessMove.InRange() {
+      fmt.Printf("wtf:\n%v\nmove: %v\n", aiGame.chessGame, chessMove)
+    }
+  }
+  return moves
+}
+
+func (aiGame *AiGame) GetNoisyMoves() []minimax.MiniMaxMove {
+  chessMoves := aiGame.TypedAiGame.GetNoisyMoves()
+  moves := make([]minimax.MiniMaxMove, len(chessMoves))
+  for i, chessMove := range chessMoves {
+    moves[i] = chessMove
+  }
+  return moves
+}
+
+func (aiGame *AiGame) MakeMove(move minimax.MiniMaxMove) {
+  aiGame.TypedAiGame.MakeMove(move.(*game.Move))
+}
+
+func (aiGame *AiGame) MovePriority(move minimax.MiniMaxMove) int {
+  return aiGame.TypedAiGame.MovePriority(move.(*game.Move))
+}
+
+func (aiGame *AiGame) Clone() minimax.MiniMaxGame {
+  return MakeAiGame(aiGame.chessGame.Clone())
+}
+
+// Deep enough that a timed search always runs out of time first.
+const kMaxTimedDepth = 100
+
 type AiPlayer struct {
-  aiGame *AiGame
-  state *minimax.MiniMaxState
+  aiGame *TypedAiGame
+  state *minimax.State[*game.Move]
+  // If non-zero, search for this long instead of to a fixed depth.
+  budget time.Duration
+  pondering bool
 }
 
 func MakeAiPlayer(
   color game.Color, chessGame *game.Game, depth int,
 ) game.Player {
-  aiGame := &AiGame{chessGame}
-  return &AiPlayer{aiGame, minimax.MakeState(aiGame, color == game.Black, depth)}
+  aiGame := MakeTypedAiGame(chessGame)
+  return &AiPlayer{
+    aiGame,
+    minimax.MakeGameState[*game.Move](aiGame, color == game.Black, depth), 0,
+    false}
+}
+
+func MakeTimedAiPlayer(
+  color game.Color, chessGame *game.Game, budget time.Duration,
+) game.Player {
+  aiGame := MakeTypedAiGame(chessGame)
+  return &AiPlayer{
+    aiGame,
+    minimax.MakeGameState[*game.Move](
+        aiGame, color == game.Black, kMaxTimedDepth),
+    budget, false}
+}
+
+// Varies the player's moves, reproducibly for a given seed. See
+// minimax.State.SetRandomSeed.
+func (player *AiPlayer) SetRandomSeed(seed int64) {
+  player.state.SetRandomSeed(seed)
+}
+
+// Lets the player pick moves a little worse than the best. See
+// minimax.State.SetTemperature.
+func (player *AiPlayer) SetTemperature(temperature float64) {
+  player.state.SetTemperature(temperature)
+}
+
+// Records the tree each search explores, up to depth moves ahead, to explain
+// the moves it picks. See minimax.State.SetTraceDepth.
+func (player *AiPlayer) SetTraceDepth(depth int) {
+  player.state.SetTraceDepth(depth)
+}
+
+// Returns the tree the last finished depth of the last search explored, or
+// nil if tracing is off.
+func (player *AiPlayer) GetTrace() *minimax.TraceNode {
+  return player.state.GetTrace()
+}
+
+// Pondering is off by default. With it on, the player keeps searching on the
+// opponent's time, assuming they'll play the reply it expects. See
+// minimax.State.StartPondering.
+func (player *AiPlayer) SetPondering(enabled bool) {
+  player.pondering = enabled
+  if !enabled {
+    player.state.StopPondering()
+  }
+}
+
+// Called with the result of every finished depth of each search, e.g. to log
+// how the player's thinking develops. See minimax.State.SetProgressCallback.
+func (player *AiPlayer) SetProgressCallback(
+  callback func(result *minimax.Result[*game.Move]),
+) {
+  player.state.SetProgressCallback(callback)
+}
+
+// Returns the reply the player is pondering on, or false if it isn't.
+func (player *AiPlayer) GetPonderMove() (*game.Move, bool) {
+  return player.state.GetPonderMove()
 }
 
 func (player *AiPlayer) GetMove() *game.Move {
-  move := player.state.GetMove()
-  fmt.Printf("game score: %v\n", player.aiGame.GetScore())
-  fmt.Printf("chose move: %v\n", move)
-  return move.(*game.Move)
+  move, _ := player.GetMoveContext(context.Background())
+  return move
+}
+
+// Like GetMove, but stops early if ctx is done, e.g. because the opponent
+// resigned. Also returns false if it stopped early, in which case the move is
+// the best found so far.
+func (player *AiPlayer) GetMoveContext(
+  ctx context.Context,
+) (*game.Move, bool) {
+  searchCtx := ctx
+  if player.budget > 0 {
+    var cancel context.CancelFunc
+    searchCtx, cancel = context.WithTimeout(ctx, player.budget)
+    defer cancel()
+  }
+  result := player.state.Search(searchCtx)
+  complete := result.Complete
+  if player.budget > 0 {
+    // Running out of time is how a timed search normally ends.
+    complete = ctx.Err() == nil
+  }
+  if player.pondering && result.HasMove {
+    player.state.StartPondering(result.Move)
+  }
+  return result.Move, complete
+}
+
+// Returns up to n of the best moves with their scores, best first, for
+// analysis and hints.
+func (player *AiPlayer) GetTopMoves(
+  n int,
+) []*minimax.Ranked[*game.Move] {
+  return player.state.GetTopMoves(n)
 }
@@ -1,7 +1,13 @@
 package ai
 
+import "context"
 import "jsdu/chess/game"
+import "mcts"
+import "minimax"
+import "reflect"
+import "runtime"
 import "testing"
+import "time"
 
 /*
 func TestGetMoveBug(t *testing.T) {
@@ -22,12 +28,615 @@ Bug with depth 4
 b2b3 d7d5 g1h3 c8h3 g2h3 c7c5 c2c4 h7h6 e2e4 d5c4 f1c4 a7a5 c1a3 f7f5 c4g8 h8g8 a3c5 b7b6 c5e7 d8e7 d1f3 e7e4 f3e4 f5e4 a2a4 e8e7 b1c3
 */
 
-func BenchmarkGetMove(b *testing.B) {
+func checkAlphaBeta(t *testing.T, moves []string, minimize bool, depth int) {
   chessGame := game.MakeGame()
-  whitePlayer := MakeAiPlayer(game.White, chessGame, 5)
+  game.MakeMoves(chessGame, moves)
+  aiGame := MakeAiGame(chessGame)
+  plain := minimax.MakeState(aiGame, minimize, depth)
+  plain.SetAlphaBeta(false)
+  // Quiescence search without pruning takes far too long.
+  plain.SetQuiescence(false)
+  pruned := minimax.MakeState(aiGame, minimize, depth)
+  pruned.SetQuiescence(false)
+
+  want := plain.GetMove()
+  got := pruned.GetMove()
+
+  if got.(*game.Move).String() != want.(*game.Move).String() {
+    t.Errorf("game:\n%v\ngot move: %v\nwant: %v", chessGame, got, want)
+  }
+  if pruned.GetNodeCount() >= plain.GetNodeCount() {
+    t.Errorf(
+        "game:\n%v\ngot nodes: %v\nwant fewer than: %v", chessGame,
+        pruned.GetNodeCount(), plain.GetNodeCount())
+  }
+}
+
+func TestAlphaBeta_Start(t *testing.T) {
+  checkAlphaBeta(t, []string{}, false, 3)
+}
+
+func TestAlphaBeta_HangingQueen(t *testing.T) {
+  checkAlphaBeta(t, []string{"e2e4", "d7d5", "d1h5"}, true, 3)
+}
+
+func TestAlphaBeta_Exchange(t *testing.T) {
+  checkAlphaBeta(
+      t, []string{"e2e4", "e7e5", "g1f3", "b8c6", "f1c4", "g8f6", "f3g5"},
+      true, 3)
+}
+
+func benchmarkGetMove(b *testing.B, alphaBeta bool) {
+  chessGame := game.MakeGame()
+  whitePlayer := MakeAiPlayer(game.White, chessGame, 5).(*AiPlayer)
+  whitePlayer.state.SetAlphaBeta(alphaBeta)
+  // Quiescence search without pruning takes far too long.
+  whitePlayer.state.SetQuiescence(false)
   b.ResetTimer()
 
+  nodes := 0
   for i := 0; i < b.N; i++ {
     whitePlayer.GetMove()
+    nodes += whitePlayer.state.GetNodeCount()
+  }
+  b.ReportMetric(float64(nodes) / float64(b.N), "nodes/op")
+}
+
+func BenchmarkGetMove(b *testing.B) {
+  benchmarkGetMove(b, true)
+}
+
+func BenchmarkGetMove_NoAlphaBeta(b *testing.B) {
+  benchmarkGetMove(b, false)
+}
+
+func benchmarkSearch[M any](b *testing.B, state *minimax.State[M]) {
+  state.SetQuiescence(false)
+  b.ReportAllocs()
+  b.ResetTimer()
+  for i := 0; i < b.N; i++ {
+    state.GetTable().Clear()
+    state.GetMove()
+  }
+}
+
+func BenchmarkSearch_Interface(b *testing.B) {
+  benchmarkSearch(b, minimax.MakeState(MakeAiGame(game.MakeGame()), false, 4))
+}
+
+func BenchmarkSearch_Typed(b *testing.B) {
+  benchmarkSearch(
+      b, minimax.MakeGameState[*game.Move](
+          MakeTypedAiGame(game.MakeGame()), false, 4))
+}
+
+func TestRandomSeed(t *testing.T) {
+  moves := make(map[string]bool)
+  for seed := int64(0); seed < 5; seed++ {
+    chessGame := game.MakeGame()
+    player := MakeAiPlayer(game.White, chessGame, 2).(*AiPlayer)
+    player.SetRandomSeed(seed)
+    again := MakeAiPlayer(game.White, chessGame, 2).(*AiPlayer)
+    again.SetRandomSeed(seed)
+
+    move := player.GetMove().String()
+
+    if againMove := again.GetMove().String(); againMove != move {
+      t.Errorf("seed: %v\ngot move: %v\nthen: %v", seed, move, againMove)
+    }
+    moves[move] = true
+  }
+  // Every opening move scores the same two moves ahead.
+  if len(moves) < 2 {
+    t.Errorf("got moves: %v\nwant different ones", moves)
+  }
+}
+
+func TestTrace(t *testing.T) {
+  chessGame := game.MakeGame()
+  game.MakeMoves(chessGame, []string{"e2e4", "e7e5", "g1f3", "d8g5"})
+  player := MakeAiPlayer(game.White, chessGame, 3).(*AiPlayer)
+  player.SetTraceDepth(2)
+
+  move := player.GetMove()
+
+  trace := player.GetTrace()
+  if trace == nil || len(trace.Children) != len(chessGame.GetAllMoves()) {
+    t.Fatalf("got trace: %v\nwant every move searched", trace)
+  }
+  var best *minimax.TraceNode
+  for _, child := range trace.Children {
+    if len(child.Children) == 0 {
+      t.Errorf("got move: %v\nwant replies traced", child.Move)
+    }
+    if child.Move == move.String() {
+      best = child
+    }
+  }
+  if best == nil || best.Score != trace.Score {
+    t.Errorf("got best: %v\nwant move %v scoring %v", best, move, trace.Score)
+  }
+}
+
+func TestPondering(t *testing.T) {
+  chessGame := game.MakeGame()
+  game.MakeMoves(chessGame, []string{"e2e4", "e7e5", "g1f3"})
+  player := MakeAiPlayer(game.Black, chessGame, 3).(*AiPlayer)
+  player.SetPondering(true)
+  chessGame.MakeMove(player.GetMove())
+  reply, ok := player.GetPonderMove()
+  if !ok {
+    t.Fatal("not pondering")
+  }
+  chessGame.MakeMove(reply)
+
+  got := player.GetMove()
+
+  fresh := MakeAiPlayer(game.Black, chessGame, 3).(*AiPlayer)
+  if want := fresh.GetMove(); got.String() != want.String() {
+    t.Errorf("game:\n%v\ngot move: %v\nwant: %v", chessGame, got, want)
+  }
+  player.SetPondering(false)
+}
+
+func TestProveMate(t *testing.T) {
+  chessGame := game.MakeGame()
+  game.MakeMoves(
+      chessGame, []string{"e2e4", "e7e5", "f1c4", "b8c6", "d1h5", "g8f6"})
+
+  proof := ProveMate(chessGame, 10000)
+
+  if proof.Status != minimax.Proven || proof.Move.String() != "{h5f7}" {
+    t.Errorf(
+        "game:\n%v\ngot status: %v move: %v\nwant proven with h5f7",
+        chessGame, proof.Status, proof.Move)
+  }
+}
+
+func TestProveMate_NodeLimit(t *testing.T) {
+  proof := ProveMate(game.MakeGame(), 100)
+
+  if proof.Status != minimax.Unproven || proof.Nodes > 200 {
+    t.Errorf("got proof: %v\nwant unproven near 100 nodes", proof)
+  }
+}
+
+func TestIterativeDeepening_NodeLimit(t *testing.T) {
+  chessGame := game.MakeGame()
+  game.MakeMoves(chessGame, []string{"e2e4", "e7e5", "g1f3", "d8g5"})
+  state := minimax.MakeState(MakeAiGame(chessGame), false, kMaxTimedDepth)
+
+  move := state.IterativeDeepening(0, 5000)
+
+  if state.GetNodeCount() > 5000 {
+    t.Errorf("got nodes: %v\nwant at most: 5000", state.GetNodeCount())
+  }
+  if move.(*game.Move).String() != game.ParseMove("f3g5").String() {
+    t.Errorf("game:\n%v\ngot move: %v\nwant: f3g5", chessGame, move)
+  }
+}
+
+func TestIterativeDeepening_Budget(t *testing.T) {
+  chessGame := game.MakeGame()
+  state := minimax.MakeState(MakeAiGame(chessGame), false, kMaxTimedDepth)
+  start := time.Now()
+
+  move := state.IterativeDeepening(200 * time.Millisecond, 0)
+
+  if elapsed := time.Since(start); elapsed > time.Second {
+    t.Errorf("got elapsed: %v\nwant about 200ms", elapsed)
+  }
+  if !chessGame.MakeMove(move.(*game.Move)) {
+    t.Errorf("game:\n%v\nAI move: %v is illegal", chessGame, move)
+  }
+}
+
+func TestRootWorkers_MatchesSequential(t *testing.T) {
+  for _, moves := range [][]string{
+      {}, {"e2e4", "e7e5", "g1f3", "d8g5"}, {"e2e4", "d7d5", "d1h5"}} {
+    chessGame := game.MakeGame()
+    game.MakeMoves(chessGame, moves)
+    minimize := chessGame.Turn() == game.Black
+    sequential := minimax.MakeState(MakeAiGame(chessGame), minimize, 3)
+    parallel := minimax.MakeState(MakeAiGame(chessGame), minimize, 3)
+    parallel.SetRootWorkers(4)
+
+    want := sequential.GetMove().(*game.Move)
+    got := parallel.GetMove().(*game.Move)
+
+    if got.String() != want.String() {
+      t.Errorf("game:\n%v\ngot move: %v\nwant: %v", chessGame, got, want)
+    }
+  }
+}
+
+func BenchmarkGetMove_RootWorkers(b *testing.B) {
+  chessGame := game.MakeGame()
+  state := minimax.MakeState(MakeAiGame(chessGame), false, 4)
+  state.SetRootWorkers(runtime.GOMAXPROCS(0))
+  b.ResetTimer()
+
+  for i := 0; i < b.N; i++ {
+    state.GetMove()
+  }
+}
+
+func TestLazySmp_OneThreadIsSequential(t *testing.T) {
+  chessGame := game.MakeGame()
+  game.MakeMoves(chessGame, []string{"e2e4", "d7d5"})
+  sequential := minimax.MakeState(MakeAiGame(chessGame), false, 3)
+  lazySmp := minimax.MakeState(MakeAiGame(chessGame), false, 3)
+  lazySmp.SetLazySmpThreads(1)
+
+  want := sequential.GetMove().(*game.Move)
+  got := lazySmp.GetMove().(*game.Move)
+
+  if got.String() != want.String() ||
+      lazySmp.GetNodeCount() != sequential.GetNodeCount() {
+    t.Errorf(
+        "game:\n%v\ngot move: %v, nodes: %v\nwant: %v, nodes: %v", chessGame,
+        got, lazySmp.GetNodeCount(), want, sequential.GetNodeCount())
+  }
+}
+
+func TestLazySmp_Threads(t *testing.T) {
+  chessGame := game.MakeGame()
+  game.MakeMoves(chessGame, []string{"e2e4", "e7e5", "g1f3", "d8g5"})
+  state := minimax.MakeState(MakeAiGame(chessGame), false, kMaxTimedDepth)
+  state.SetLazySmpThreads(4)
+
+  move := state.IterativeDeepening(0, 20000)
+
+  if move.(*game.Move).String() != game.ParseMove("f3g5").String() {
+    t.Errorf("game:\n%v\ngot move: %v\nwant: f3g5", chessGame, move)
+  }
+}
+
+// Run with -cpu 1,2,4 to see how the time to reach a fixed depth scales.
+// Nodes/s would count the work the helpers repeat.
+func BenchmarkLazySmp(b *testing.B) {
+  chessGame := game.MakeGame()
+  game.MakeMoves(chessGame, []string{"e2e4", "e7e5", "g1f3", "b8c6"})
+  state := minimax.MakeState(MakeAiGame(chessGame), false, 4)
+  state.SetLazySmpThreads(runtime.GOMAXPROCS(0))
+  b.ResetTimer()
+
+  for i := 0; i < b.N; i++ {
+    // Start from scratch every time.
+    state.GetTable().Clear()
+    state.IterativeDeepening(0, 0)
+  }
+}
+
+func TestGetMoveContext_Complete(t *testing.T) {
+  chessGame := game.MakeGame()
+  game.MakeMoves(chessGame, []string{"e2e4", "e7e5", "g1f3", "d8g5"})
+  player := MakeAiPlayer(game.White, chessGame, 3).(*AiPlayer)
+
+  move, complete := player.GetMoveContext(context.Background())
+
+  if !complete || move.String() != game.ParseMove("f3g5").String() {
+    t.Errorf(
+        "game:\n%v\ngot move: %v, complete: %v\nwant: f3g5, true", chessGame,
+        move, complete)
+  }
+}
+
+func TestGetMoveContext_Cancel(t *testing.T) {
+  chessGame := game.MakeGame()
+  player := MakeAiPlayer(game.White, chessGame, kMaxTimedDepth).(*AiPlayer)
+  ctx, cancel := context.WithCancel(context.Background())
+  time.AfterFunc(100 * time.Millisecond, cancel)
+  start := time.Now()
+
+  move, complete := player.GetMoveContext(ctx)
+
+  if elapsed := time.Since(start); elapsed > time.Second {
+    t.Errorf("got elapsed: %v\nwant about 100ms", elapsed)
+  }
+  if complete {
+    t.Errorf("got complete after cancel")
+  }
+  if !chessGame.MakeMove(move) {
+    t.Errorf("game:\n%v\nAI move: %v is illegal", chessGame, move)
+  }
+}
+
+func TestGetMoveContext_AlreadyCancelled(t *testing.T) {
+  chessGame := game.MakeGame()
+  player := MakeTimedAiPlayer(game.Black, chessGame, time.Second).(*AiPlayer)
+  game.MakeMoves(chessGame, []string{"e2e4"})
+  ctx, cancel := context.WithCancel(context.Background())
+  cancel()
+
+  move, complete := player.GetMoveContext(ctx)
+
+  if complete {
+    t.Errorf("got complete after cancel")
+  }
+  if !chessGame.MakeMove(move) {
+    t.Errorf("game:\n%v\nAI move: %v is illegal", chessGame, move)
+  }
+}
+
+func TestSearch_Progress(t *testing.T) {
+  chessGame := game.MakeGame()
+  game.MakeMoves(chessGame, []string{"e2e4", "e7e5", "g1f3", "d8g5"})
+  state := minimax.MakeState(MakeAiGame(chessGame), false, 3)
+  depths := []int{}
+  state.SetProgressCallback(func(result *minimax.SearchResult) {
+    depths = append(depths, result.Depth)
+  })
+
+  result := state.Search(context.Background())
+
+  if !reflect.DeepEqual(depths, []int{1, 2, 3}) {
+    t.Errorf("got progress depths: %v\nwant: [1 2 3]", depths)
+  }
+  if result.Depth != 3 || !result.Complete ||
+      result.Nodes != state.GetNodeCount() || result.Score < 9 {
+    t.Errorf("got result: %v\nwant depth 3, score >= 9, complete", result)
+  }
+  pv := result.PrincipalVariation
+  if len(pv) != 3 || pv[0] != result.Move {
+    t.Fatalf("got pv: %v\nwant 3 moves starting with %v", pv, result.Move)
+  }
+  for _, move := range pv {
+    if !chessGame.MakeMove(move.(*game.Move)) {
+      t.Errorf("game:\n%v\npv move: %v is illegal", chessGame, move)
+    }
+  }
+}
+
+func TestGetTopMoves(t *testing.T) {
+  chessGame := game.MakeGame()
+  game.MakeMoves(chessGame, []string{"e2e4", "e7e5", "g1f3", "d8g5"})
+  player := MakeAiPlayer(game.White, chessGame, 2).(*AiPlayer)
+
+  top := player.GetTopMoves(3)
+
+  if len(top) != 3 || top[0].Move.String() != "{f3g5}" {
+    t.Fatalf("game:\n%v\ngot top moves: %v\nwant f3g5 first", chessGame, top)
+  }
+  if top[0].Score <= top[1].Score || top[1].Score < top[2].Score {
+    t.Errorf(
+        "got scores: %v %v %v\nwant best first", top[0].Score, top[1].Score,
+        top[2].Score)
+  }
+}
+
+func checkQuiescence(t *testing.T, quiescence bool, wantScore minimax.Score) {
+  chessGame := game.MakeGame()
+  game.MakeMoves(chessGame, []string{"e2e4", "e7e5", "d1h5", "b8c6"})
+  state := minimax.MakeState(MakeAiGame(chessGame), false, 1)
+  state.SetQuiescence(quiescence)
+
+  result := state.Search(context.Background())
+
+  if result.Score != wantScore {
+    t.Errorf(
+        "game:\n%v\ngot result: %v\nwant score: %v", chessGame, result,
+        wantScore)
+  }
+}
+
+func TestQuiescence_SeesRecapture(t *testing.T) {
+  checkQuiescence(t, true, 0)
+}
+
+func TestQuiescence_Off(t *testing.T) {
+  // Qxf7+ looks like it wins a pawn, but the king takes the queen.
+  checkQuiescence(t, false, 1)
+}
+
+func TestNullMovePruning_SameMoveFewerNodes(t *testing.T) {
+  chessGame := game.MakeGame()
+  game.MakeMoves(chessGame, []string{"e2e4", "e7e5", "g1f3", "d8g5"})
+  full := minimax.MakeState(MakeAiGame(chessGame), false, 5)
+  pruned := minimax.MakeState(MakeAiGame(chessGame), false, 5)
+  pruned.SetNullMovePruning(true)
+  hash := chessGame.Hash()
+
+  want := full.GetMove().(*game.Move)
+  got := pruned.GetMove().(*game.Move)
+
+  if got.String() != want.String() {
+    t.Errorf("game:\n%v\ngot move: %v\nwant: %v", chessGame, got, want)
+  }
+  if pruned.GetNodeCount() >= full.GetNodeCount() {
+    t.Errorf(
+        "game:\n%v\ngot nodes: %v\nwant fewer than: %v", chessGame,
+        pruned.GetNodeCount(), full.GetNodeCount())
+  }
+  if chessGame.Hash() != hash {
+    t.Errorf("game:\n%v\nsearch changed the game", chessGame)
+  }
+}
+
+func TestCanMakeNullMove(t *testing.T) {
+  chessGame := game.MakeGame()
+  aiGame := MakeAiGame(chessGame)
+  if !aiGame.CanMakeNullMove() {
+    t.Errorf("game:\n%v\ngot: can't make null move\nwant: can", chessGame)
+  }
+
+  game.MakeMoves(chessGame, []string{"e2e4", "f7f6", "d1h5"})
+
+  if aiGame.CanMakeNullMove() {
+    t.Errorf("game:\n%v\ngot: can make null move in check\nwant: can't",
+        chessGame)
+  }
+}
+
+func checkTactics(t *testing.T, configure func(state *minimax.MiniMaxState)) {
+  for _, tactic := range game.GetTactics() {
+    chessGame := tactic.Load()
+    state := minimax.MakeState(
+        MakeAiGame(chessGame), tactic.Turn == game.Black, 4)
+    configure(state)
+
+    move := state.GetMove().(*game.Move)
+
+    if want := game.ParseMove(tactic.Best); move.String() != want.String() {
+      t.Errorf(
+          "%v:\n%v\ngot move: %v\nwant: %v", tactic.Name, chessGame, move,
+          want)
+    }
+  }
+}
+
+func TestTactics(t *testing.T) {
+  checkTactics(t, func(state *minimax.MiniMaxState) {})
+}
+
+func TestTactics_LateMoveReductions(t *testing.T) {
+  checkTactics(t, func(state *minimax.MiniMaxState) {
+    state.SetLateMoveReductions(true)
+  })
+}
+
+func TestTactics_FutilityPruning(t *testing.T) {
+  checkTactics(t, func(state *minimax.MiniMaxState) {
+    state.SetFutilityPruning(true)
+  })
+}
+
+func TestTactics_AllPruning(t *testing.T) {
+  checkTactics(t, func(state *minimax.MiniMaxState) {
+    state.SetNullMovePruning(true)
+    state.SetLateMoveReductions(true)
+    state.SetFutilityPruning(true)
+  })
+}
+
+func checkFewerNodes(
+    t *testing.T, configure func(state *minimax.MiniMaxState)) {
+  chessGame := game.MakeGame()
+  game.MakeMoves(chessGame, []string{"e2e4", "e7e5", "g1f3", "b8c6"})
+  full := minimax.MakeState(MakeAiGame(chessGame), false, 5)
+  pruned := minimax.MakeState(MakeAiGame(chessGame), false, 5)
+  configure(pruned)
+
+  full.GetMove()
+  pruned.GetMove()
+
+  if pruned.GetNodeCount() >= full.GetNodeCount() {
+    t.Errorf(
+        "game:\n%v\ngot nodes: %v\nwant fewer than: %v", chessGame,
+        pruned.GetNodeCount(), full.GetNodeCount())
+  }
+}
+
+func TestLateMoveReductions_FewerNodes(t *testing.T) {
+  checkFewerNodes(t, func(state *minimax.MiniMaxState) {
+    state.SetLateMoveReductions(true)
+  })
+}
+
+func TestFutilityPruning_FewerNodes(t *testing.T) {
+  checkFewerNodes(t, func(state *minimax.MiniMaxState) {
+    state.SetFutilityPruning(true)
+  })
+}
+
+func TestTactics_PrincipalVariationSearch(t *testing.T) {
+  checkTactics(t, func(state *minimax.MiniMaxState) {
+    state.SetPrincipalVariationSearch(true)
+  })
+}
+
+func TestAspirationWindow_MatchesFullWindow(t *testing.T) {
+  for _, tactic := range game.GetTactics() {
+    chessGame := tactic.Load()
+    minimize := tactic.Turn == game.Black
+    full := minimax.MakeState(MakeAiGame(chessGame), minimize, 4)
+    aspiration := minimax.MakeState(MakeAiGame(chessGame), minimize, 4)
+    aspiration.SetAspirationWindow(1)
+
+    want := full.Search(context.Background())
+    got := aspiration.Search(context.Background())
+
+    if got.Move.(*game.Move).String() != want.Move.(*game.Move).String() ||
+        got.Score != want.Score {
+      t.Errorf(
+          "%v:\n%v\ngot result: %v\nwant: %v", tactic.Name, chessGame, got,
+          want)
+    }
+  }
+}
+
+func TestMtdf_MatchesFullWindow(t *testing.T) {
+  for _, tactic := range game.GetTactics() {
+    chessGame := tactic.Load()
+    minimize := tactic.Turn == game.Black
+    full := minimax.MakeState(MakeAiGame(chessGame), minimize, 4)
+    mtdf := minimax.MakeState(MakeAiGame(chessGame), minimize, 4)
+    mtdf.SetMtdf(true)
+
+    want := full.Search(context.Background())
+    got := mtdf.Search(context.Background())
+
+    if got.Move.(*game.Move).String() != want.Move.(*game.Move).String() ||
+        got.Score != want.Score {
+      t.Errorf(
+          "%v:\n%v\ngot result: %v\nwant: %v", tactic.Name, chessGame, got,
+          want)
+    }
+  }
+}
+
+// Searches the start and every tactic with iterative deepening, from an
+// empty table each time.
+func benchmarkDriver(b *testing.B, mtdf bool) {
+  positions := []*game.Game{game.MakeGame()}
+  states := []*minimax.State[*game.Move]{}
+  for _, tactic := range game.GetTactics() {
+    positions = append(positions, tactic.Load())
+  }
+  for _, position := range positions {
+    state := minimax.MakeGameState[*game.Move](
+        MakeTypedAiGame(position), position.Turn() == game.Black, 4)
+    state.SetMtdf(mtdf)
+    states = append(states, state)
+  }
+  nodes := 0
+  b.ResetTimer()
+
+  for i := 0; i < b.N; i++ {
+    for _, state := range states {
+      state.GetTable().Clear()
+      state.IterativeDeepening(0, 0)
+      nodes += state.GetNodeCount()
+    }
+  }
+  b.ReportMetric(float64(nodes) / float64(b.N), "nodes/op")
+}
+
+func BenchmarkDriver_AlphaBeta(b *testing.B) {
+  benchmarkDriver(b, false)
+}
+
+func BenchmarkDriver_Mtdf(b *testing.B) {
+  benchmarkDriver(b, true)
+}
+
+func TestMcts_Tactics(t *testing.T) {
+  for _, tactic := range game.GetTactics() {
+    chessGame := tactic.Load()
+    state := mcts.MakeState(MakeAiGame(chessGame), tactic.Turn == game.Black)
+    state.SetIterations(800)
+    state.SetMaxPlayoutDepth(kMctsPlayoutDepth)
+
+    move := state.GetMove().(*game.Move)
+
+    // Playouts only score wins and losses, so they can't tell which piece
+    // to promote to.
+    want := game.ParseMove(tactic.Best)
+    if move.From().String() != want.From().String() ||
+        move.To().String() != want.To().String() {
+      t.Errorf(
+          "%v:\n%v\ngot move: %v\nwant: %v", tactic.Name, chessGame, move,
+          want)
+    }
   }
 }
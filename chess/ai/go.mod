@@ -1,12 +1,15 @@
 module ai
 
-go 1.16
+go 1.18
 
 replace jsdu/chess/game => ../game
 
 replace minimax => ../../minimax
 
+replace mcts => ../../mcts
+
 require (
 	jsdu/chess/game v0.0.0-00010101000000-000000000000
+	mcts v0.0.0-00010101000000-000000000000
 	minimax v0.0.0-00010101000000-000000000000
 )
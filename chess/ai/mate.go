@@ -0,0 +1,37 @@
+package ai
+
+import (
+  "jsdu/chess/game"
+  "minimax"
+)
+
+// Only scores checkmates, as wins, for proof-number search. Draws end the
+// game.
+type mateGame struct {
+  *TypedAiGame
+}
+
+func (mateGame *mateGame) GetScore() minimax.Score {
+  switch mateGame.chessGame.GetState() {
+    case game.WhiteWins: return minimax.MaxScore
+    case game.BlackWins: return minimax.MinScore
+    default: return 0
+  }
+}
+
+func (mateGame *mateGame) GetAllMoves() []*game.Move {
+  if mateGame.chessGame.GetState() == game.Draw {
+    return nil
+  }
+  return mateGame.chessGame.GetAllMoves()
+}
+
+// Looks for a forced checkmate by the side to move with proof-number search,
+// which needs no depth limit. See minimax.ProveWin.
+func ProveMate(
+  chessGame *game.Game, nodeLimit int,
+) *minimax.Proof[*game.Move] {
+  return minimax.ProveWin[*game.Move](
+      &mateGame{MakeTypedAiGame(chessGame)}, chessGame.Turn() == game.Black,
+      nodeLimit)
+}
@@ -0,0 +1,33 @@
+package ai
+
+import (
+  "jsdu/chess/game"
+  "mcts"
+  "time"
+)
+
+// Random games rarely end in mate, so playouts stop after this many moves and
+// are scored by material.
+const kMctsPlayoutDepth = 8
+
+// Plays with Monte Carlo tree search instead of minimax.
+type MctsPlayer struct {
+  state *mcts.MctsState
+}
+
+func MakeMctsPlayer(
+  color game.Color, chessGame *game.Game, budget time.Duration,
+) game.Player {
+  state := mcts.MakeState(MakeAiGame(chessGame), color == game.Black)
+  state.SetBudget(budget)
+  state.SetMaxPlayoutDepth(kMctsPlayoutDepth)
+  return &MctsPlayer{state}
+}
+
+func (player *MctsPlayer) GetMove() *game.Move {
+  move := player.state.GetMove()
+  if move == nil {
+    return nil
+  }
+  return move.(*game.Move)
+}
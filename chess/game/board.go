@@ -18,11 +18,13 @@ type Board struct {
   whitePoints int
   blackPoints int
   stringKey []byte
+  hash uint64
 }
 
 type BoardView interface {
   Get(coord *Coord) *Piece
   StringKey() string
+  Hash() uint64
   GetPieces(color Color) map[int]*Piece
   GetPoints(color Color) int
 }
@@ -61,13 +63,31 @@ func MakeBoard() *Board {
 func EmptyBoard() *Board {
   board := &Board{
     &Rows{}, nil, nil, make(map[int]*Piece), make(map[int]*Piece), 0, 0,
-    make([]byte, 64)}
+    make([]byte, 64), 0}
   for i := 0; i < 64; i++ {
     board.stringKey[i] = ' '
   }
   return board
 }
 
+// Pieces and coords are never modified, so the copy shares them.
+func (board *Board) clone() *Board {
+  rows := *board.rows
+  clone := &Board{
+    &rows, board.whiteKingPos, board.blackKingPos,
+    make(map[int]*Piece, len(board.whitePieces)),
+    make(map[int]*Piece, len(board.blackPieces)), board.whitePoints,
+    board.blackPoints, make([]byte, 64), board.hash}
+  for key, piece := range board.whitePieces {
+    clone.whitePieces[key] = piece
+  }
+  for key, piece := range board.blackPieces {
+    clone.blackPieces[key] = piece
+  }
+  copy(clone.stringKey, board.stringKey)
+  return clone
+}
+
 func (board *Board) Get(coord *Coord) *Piece {
   if coord != nil && coord.InRange() {
     return board.rows[coord.row][coord.col]
@@ -79,7 +99,10 @@ func (board *Board) Set(coord *Coord, piece *Piece) {
   if !coord.InRange() {
     panic(fmt.Sprintf("board:\n%v\ncoord out of range %v", board, coord))
   }
-  if existing := board.rows[coord.row][coord.col]; existing != nil {
+  index := coord.toArrayIndex()
+  existing := board.rows[coord.row][coord.col]
+  board.hash ^= zobristPiece(index, existing) ^ zobristPiece(index, piece)
+  if existing != nil {
     if existing.color == Black {
       board.blackPoints -= existing.GetPoints()
       delete(board.blackPieces, coord.toKey())
@@ -103,7 +126,7 @@ func (board *Board) Set(coord *Coord, piece *Piece) {
       }
     }
   }
-  board.stringKey[coord.toArrayIndex()] = piece.toByte()
+  board.stringKey[index] = piece.toByte()
   board.rows[coord.row][coord.col] = piece
 }
 
@@ -165,3 +188,8 @@ func printRow(row int, board *Board, builder *strings.Builder) {
 func (board *Board) StringKey() string {
   return string(board.stringKey[:])
 }
+
+// Zobrist hash of the pieces. Cheaper than StringKey, but may collide.
+func (board *Board) Hash() uint64 {
+  return board.hash
+}
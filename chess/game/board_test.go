@@ -161,3 +161,44 @@ func TestGetPoints_RandomMovesAndUndo(t *testing.T) {
   }
 }
 */
+
+func TestHash_SamePositionDifferentOrder(t *testing.T) {
+  game := MakeGame()
+  MakeMoves(game, []string{"e2e4", "e7e5", "g1f3", "b8c6"})
+  other := MakeGame()
+  MakeMoves(other, []string{"g1f3", "b8c6", "e2e4", "e7e5"})
+
+  if game.Hash() != other.Hash() {
+    t.Errorf("game:\n%v\nother:\n%v\nwant same hash", game, other)
+  }
+}
+
+func TestHash_UndoMove(t *testing.T) {
+  game := MakeGame()
+  want := game.Hash()
+  MakeMoves(game, []string{"e2e4", "d7d5", "e4d5"})
+  if game.Hash() == want {
+    t.Errorf("game:\n%v\nhash didn't change", game)
+  }
+
+  for i := 0; i < 3; i++ {
+    game.UndoMove()
+  }
+
+  if got := game.Hash(); got != want {
+    t.Errorf("game:\n%v\ngot hash: %v\nwant: %v", game, got, want)
+  }
+}
+
+func TestHash_SideToMove(t *testing.T) {
+  game := MakeGame()
+  MakeMoves(game, []string{"g1f3"})
+
+  if game.Hash() == game.GetBoard().Hash() {
+    t.Errorf("game:\n%v\nblack to move didn't change the hash", game)
+  }
+  MakeMoves(game, []string{"g8f6", "f3g1", "f6g8"})
+  if got, want := game.Hash(), MakeGame().Hash(); got != want {
+    t.Errorf("game:\n%v\ngot hash: %v\nwant: %v", game, got, want)
+  }
+}
@@ -23,6 +23,14 @@ func MakeCoord(row int, col int) *Coord {
   return &Coord{row, col}
 }
 
+func (coord *Coord) Row() int {
+  return coord.row
+}
+
+func (coord *Coord) Col() int {
+  return coord.col
+}
+
 func (coord *Coord) InRange() bool {
   return 0 <= coord.row && coord.row <= 7 && 0 <= coord.col && coord.col <= 7
 }
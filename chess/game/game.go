@@ -10,10 +10,12 @@ type Game struct {
   board *Board
   history *History
   boardCounts map[string]int
+  // The number of events in history when each null move was made.
+  nullMoves []int
 }
 
 func LoadGame(turn Color, board *Board, history *History) *Game {
-  game := &Game{turn, board, history, make(map[string]int)}
+  game := &Game{turn, board, history, make(map[string]int), nil}
   game.boardCounts[board.StringKey()]++
   // Create boardCounts
   events := game.history.events
@@ -28,11 +30,23 @@ func LoadGame(turn Color, board *Board, history *History) *Game {
 
 func MakeGame() *Game {
   game := &Game{
-    White, MakeBoard(), MakeHistory(), make(map[string]int)}
+    White, MakeBoard(), MakeHistory(), make(map[string]int), nil}
   game.boardCounts[game.board.StringKey()]++
   return game
 }
 
+// Returns a copy that can be changed without affecting game.
+func (game *Game) Clone() *Game {
+  clone := &Game{
+    game.turn, game.board.clone(), game.history.clone(),
+    make(map[string]int, len(game.boardCounts)),
+    append([]int{}, game.nullMoves...)}
+  for key, count := range game.boardCounts {
+    clone.boardCounts[key] = count
+  }
+  return clone
+}
+
 func (game *Game) MakeMove(move *Move) bool {
   event, ok := InterpretMove(move, game)
   if !ok {
@@ -55,6 +69,36 @@ func (game *Game) UndoMove() bool {
   return game.switchTurns()
 }
 
+// Passes the turn to the other side without changing the board or history.
+// Only meant for searches, which use it to see how good a position is for the
+// other side.
+func (game *Game) MakeNullMove() {
+  game.nullMoves = append(game.nullMoves, len(game.history.events))
+  game.switchTurns()
+}
+
+func (game *Game) UndoNullMove() bool {
+  if len(game.nullMoves) == 0 {
+    return false
+  }
+  game.nullMoves = game.nullMoves[:len(game.nullMoves) - 1]
+  return game.switchTurns()
+}
+
+// Returns the other side's last move, or nil if they passed with a null move.
+func (game *Game) lastOpponentEvent() *Event {
+  if n := len(game.nullMoves);
+      n > 0 && game.nullMoves[n - 1] == len(game.history.events) {
+    return nil
+  }
+  return game.history.GetLastEvent()
+}
+
+func (game *Game) InCheck() bool {
+  kingInCheck, _ := identifyChecks(game)
+  return kingInCheck
+}
+
 func (game *Game) getNextTurn() Color {
   return game.turn.Other()
 }
@@ -111,10 +155,14 @@ func (state State) IsOver() bool {
   return false
 }
 
+// Moves are ordered by from square so that searches are reproducible.
 func (game *Game) GetAllMoves() []*Move {
   moves := make([]*Move, 0, 64)
-  for fromKey, _ := range game.board.GetPieces(game.turn) {
-    moves = append(moves, LegalMovesFrom(keyToCoord(fromKey), game)...)
+  pieces := game.board.GetPieces(game.turn)
+  for fromKey := 0; fromKey < 64; fromKey++ {
+    if _, ok := pieces[fromKey]; ok {
+      moves = append(moves, LegalMovesFrom(keyToCoord(fromKey), game)...)
+    }
   }
   return moves
 }
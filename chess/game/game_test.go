@@ -274,3 +274,58 @@ func TestRandomMoves(t *testing.T) {
   }
 }
 */
+
+func TestClone(t *testing.T) {
+  game := MakeGame()
+  MakeMoves(game, []string{"e2e4", "d7d5", "e4d5"})
+  want := game.String()
+
+  clone := game.Clone()
+  MakeMoves(clone, []string{"d8d5", "b1c3"})
+  clone.UndoMove()
+  clone.UndoMove()
+  clone.UndoMove()
+
+  if got := game.String(); got != want {
+    t.Errorf("got game:\n%v\nwant:\n%v", got, want)
+  }
+  if clone.Turn() != White {
+    t.Errorf("clone:\n%v\ngot turn: %v\nwant: %v", clone, clone.Turn(), White)
+  }
+  checkGetPieces(t, clone.board)
+  checkPiece(t, game, "d5", &Piece{'p', White})
+  checkPiece(t, clone, "e4", &Piece{'p', White})
+}
+
+func TestMakeNullMove(t *testing.T) {
+  game := MakeGame()
+  MakeMoves(game, []string{"a2a3", "e7e5", "a3a4", "d7d5"})
+  board := game.board.StringKey()
+
+  game.MakeNullMove()
+
+  if game.Turn() != Black || game.board.StringKey() != board ||
+      len(game.history.events) != 4 {
+    t.Errorf("game:\n%v\nnull move changed more than the turn", game)
+  }
+  // Black's own d7d5 mustn't look like white's last move.
+  if moves := LegalMovesFrom(ParseCoord("e5"), game); len(moves) != 1 {
+    t.Errorf("game:\n%v\ngot moves from e5: %v\nwant: [e5e4]", game, moves)
+  }
+
+  game.UndoNullMove()
+
+  if game.Turn() != White || game.board.StringKey() != board {
+    t.Errorf("game:\n%v\ngot turn: %v\nwant: %v", game, game.Turn(), White)
+  }
+}
+
+func TestGetTactics_BestIsLegal(t *testing.T) {
+  for _, tactic := range GetTactics() {
+    game := tactic.Load()
+    if !game.MakeMove(ParseMove(tactic.Best)) {
+      t.Errorf("%v:\n%v\nbest move: %v is illegal", tactic.Name, game,
+          tactic.Best)
+    }
+  }
+}
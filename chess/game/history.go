@@ -18,6 +18,21 @@ func MakeHistory() *History {
     make([]*Piece, 0, 15)}
 }
 
+// Events are never modified, so the copy shares them.
+func (history *History) clone() *History {
+  clone := &History{
+    append(make([]*Event, 0, cap(history.events)), history.events...),
+    make(map[int]int, len(history.toToCount)),
+    append(make([]*Piece, 0, cap(history.whiteCaptures)),
+           history.whiteCaptures...),
+    append(make([]*Piece, 0, cap(history.blackCaptures)),
+           history.blackCaptures...)}
+  for key, count := range history.toToCount {
+    clone.toToCount[key] = count
+  }
+  return clone
+}
+
 func (history *History) AllEvents() []*Event {
   return history.events
 }
@@ -49,6 +49,25 @@ func MakePromo(from *Coord, to *Coord, promoteTo byte) *Move {
   return &Move{from, to, promoteTo}
 }
 
+func (move *Move) From() *Coord {
+  return move.from
+}
+
+func (move *Move) To() *Coord {
+  return move.to
+}
+
+// 0 unless the move is a promotion.
+func (move *Move) PromoteTo() byte {
+  return move.promoteTo
+}
+
+// Equal moves have equal keys.
+func (move *Move) MoveKey() uint64 {
+  return uint64(move.from.toKey()) | uint64(move.to.toKey()) << 6 |
+      uint64(move.promoteTo) << 12
+}
+
 func (move *Move) Diff() (int, int) {
   from := move.from
   to := move.to
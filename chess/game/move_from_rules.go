@@ -224,7 +224,7 @@ func appendIfEmptyOrCapture(from *Coord, to *Coord, game *Game, moves []*Move) [
 }
 
 func appendIfEnPassant(from *Coord, game *Game, moves []*Move) []*Move {
-  lastEvent := game.history.GetLastEvent()
+  lastEvent := game.lastOpponentEvent()
   if lastEvent == nil {
     return moves
   }
@@ -11,6 +11,10 @@ type Piece struct {
   color Color
 }
 
+func MakePiece(name byte, color Color) *Piece {
+  return &Piece{name, color}
+}
+
 func (piece *Piece) GetName() byte {
   return piece.name
 }
@@ -130,7 +130,7 @@ func interpretPawn(piece *Piece, move *Move, game *Game) (*Event, bool) {
   if toPiece != nil {
     return badEvent()
   }
-  lastEvent := game.history.GetLastEvent()
+  lastEvent := game.lastOpponentEvent()
   if lastEvent == nil {
     return badEvent()
   }
@@ -54,3 +54,76 @@ func checkState(t *testing.T, game *Game, want State) {
     t.Errorf("game:\n%v\ngot state: %v\nwant: %v", game, got, want)
   }
 }
+
+// A position with one move that wins material, for testing searches.
+type Tactic struct {
+  Name string
+  Turn Color
+  // Rows from 1 to 8 as in loadBoard. White pieces are lower case.
+  Board string
+  Best string
+}
+
+func (tactic *Tactic) Load() *Game {
+  return LoadGame(tactic.Turn, loadBoard(tactic.Board), MakeHistory())
+}
+
+func GetTactics() []*Tactic {
+  return []*Tactic{
+    {"hanging queen", White,
+      // abcdefgh
+        "rnbqkb r" + // 1
+        "pppp ppp" + // 2
+        "     n  " + // 3
+        "    p   " + // 4
+        "    P Q " + // 5
+        "        " + // 6
+        "PPPP PPP" + // 7
+        "RNB KBNR",  // 8
+        "f3g5"},
+    {"knight fork", White,
+      // abcdefgh
+        "      k " + // 1
+        "     ppp" + // 2
+        "        " + // 3
+        "        " + // 4
+        " n      " + // 5
+        "        " + // 6
+        "     PPP" + // 7
+        "R   K   ",  // 8
+        "b5c7"},
+    {"skewer", White,
+      // abcdefgh
+        "k    b  " + // 1
+        "pp      " + // 2
+        "        " + // 3
+        "        " + // 4
+        "        " + // 5
+        "    K   " + // 6
+        "        " + // 7
+        "      Q ",  // 8
+        "f1c4"},
+    {"promotion", White,
+      // abcdefgh
+        "      k " + // 1
+        "      pp" + // 2
+        "        " + // 3
+        "        " + // 4
+        "        " + // 5
+        "        " + // 6
+        " p    PP" + // 7
+        "R N    K",  // 8
+        "b7a8q"},
+    {"black fork", Black,
+      // abcdefgh
+        "r   k   " + // 1
+        "pp   ppp" + // 2
+        "        " + // 3
+        "   N    " + // 4
+        "        " + // 5
+        "        " + // 6
+        "     PPP" + // 7
+        "    K   ",  // 8
+        "d4c2"},
+  }
+}
@@ -0,0 +1,55 @@
+package game
+
+// Random numbers for Zobrist hashing: one per piece per square, plus one for
+// black to move. Generated with splitmix64 from a fixed seed so hashes are the
+// same on every run.
+var zobristPieces [64][12]uint64
+var zobristBlackToMove uint64
+
+func init() {
+  seed := uint64(0x9e3779b97f4a7c15)
+  next := func() uint64 {
+    seed += 0x9e3779b97f4a7c15
+    z := seed
+    z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+    z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+    return z ^ (z >> 31)
+  }
+  for square := range zobristPieces {
+    for piece := range zobristPieces[square] {
+      zobristPieces[square][piece] = next()
+    }
+  }
+  zobristBlackToMove = next()
+}
+
+func zobristPiece(index int, piece *Piece) uint64 {
+  if piece == nil {
+    return 0
+  }
+  return zobristPieces[index][piece.zobristIndex()]
+}
+
+func (piece *Piece) zobristIndex() int {
+  index := 0
+  switch piece.name {
+    case 'p': index = 0
+    case 'n': index = 1
+    case 'b': index = 2
+    case 'r': index = 3
+    case 'q': index = 4
+    case 'k': index = 5
+  }
+  if piece.color == Black {
+    index += 6
+  }
+  return index
+}
+
+// Hashes the board and the side to move.
+func (game *Game) Hash() uint64 {
+  if game.turn == Black {
+    return game.board.Hash() ^ zobristBlackToMove
+  }
+  return game.board.Hash()
+}
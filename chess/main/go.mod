@@ -1,14 +1,19 @@
 module jsdu/chess/main
 
-go 1.16
+go 1.18
 
 replace jsdu/chess/game => ../game
 
 replace minimax => ../../minimax
 
+replace mcts => ../../mcts
+
 replace ai => ../ai
 
 require (
 	ai v0.0.0-00010101000000-000000000000
 	jsdu/chess/game v0.0.0-00010101000000-000000000000
+	minimax v0.0.0-00010101000000-000000000000
 )
+
+require mcts v0.0.0-00010101000000-000000000000 // indirect
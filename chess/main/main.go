@@ -2,8 +2,10 @@ package main
 
 import (
   "ai"
+  "flag"
   "fmt"
   "jsdu/chess/game"
+  "minimax"
   "time"
 )
 
@@ -54,19 +56,53 @@ func (manager *PlayerManager) GetCurrentPlayer() game.Player {
   return manager.whitePlayer
 }
 
+func makeAiPlayer(
+  color game.Color, chessGame *game.Game, seed int64, temperature float64,
+  ponder bool,
+) game.Player {
+  player := ai.MakeTimedAiPlayer(color, chessGame, 2 * time.Second)
+  player.(*ai.AiPlayer).SetRandomSeed(seed)
+  player.(*ai.AiPlayer).SetTemperature(temperature)
+  player.(*ai.AiPlayer).SetPondering(ponder)
+  player.(*ai.AiPlayer).SetProgressCallback(
+      func(result *minimax.Result[*game.Move]) {
+        fmt.Printf("%v search: %v\n", color, result)
+      })
+  return player
+}
+
 func main() {
+  seed := flag.Int64(
+      "seed", time.Now().UnixNano(), "seed for breaking ties between moves")
+  temperature := flag.Float64(
+      "temperature", 0, "softmax temperature in points, 0 for best moves only")
+  human := flag.String(
+      "human", "", "white or black to play against the AI, which ponders")
+  flag.Parse()
+  fmt.Printf("seed: %v\n", *seed)
   chessGame := game.MakeGame()
+  ponder := *human != ""
   manager := &PlayerManager{
-      ai.MakeAiPlayer(game.White, chessGame, 5),
-      ai.MakeAiPlayer(game.Black, chessGame, 5),
+      makeAiPlayer(game.White, chessGame, *seed, *temperature, ponder),
+      makeAiPlayer(game.Black, chessGame, *seed + 1, *temperature, ponder),
       chessGame}
+  switch *human {
+    case "white": manager.whitePlayer = MakeHumanPlayer(game.White, chessGame)
+    case "black": manager.blackPlayer = MakeHumanPlayer(game.Black, chessGame)
+  }
   lastTime := time.Now()
   for state := chessGame.GetState(); !state.IsOver();
       state = chessGame.GetState() {
-    move := manager.GetCurrentPlayer().GetMove()
+    player := manager.GetCurrentPlayer()
+    move := player.GetMove()
     if ok := chessGame.MakeMove(move); !ok {
       fmt.Printf("failed to make move: %v\n", move)
     }
+    if aiPlayer, ok := player.(*ai.AiPlayer); ok {
+      if reply, ok := aiPlayer.GetPonderMove(); ok {
+        fmt.Printf("pondering reply: %v\n", reply)
+      }
+    }
     fmt.Println(chessGame)
     currentTime := time.Now()
     fmt.Printf(
@@ -0,0 +1,7 @@
+module mcts
+
+go 1.16
+
+replace minimax => ../minimax
+
+require minimax v0.0.0-00010101000000-000000000000
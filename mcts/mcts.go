@@ -0,0 +1,259 @@
+package mcts
+
+import (
+  "hash/fnv"
+  "math"
+  "math/rand"
+  "minimax"
+  "time"
+)
+
+// The usual exploration constant for UCT, sqrt(2).
+const kDefaultExploration = math.Sqrt2
+
+// Iterations per move if neither an iteration count nor a budget is set.
+const kDefaultIterations = 10000
+
+// Checking the clock is slow compared to an iteration.
+const kIterationsPerClockCheck = 64
+
+// A position in the search tree.
+type node struct {
+  // The move that led here from the parent. nil at the root.
+  move minimax.MiniMaxMove
+  parent *node
+  children []*node
+  // Moves not yet expanded into children.
+  untried []minimax.MiniMaxMove
+  // True if the side to move here is the minimizing side.
+  minimize bool
+  key uint64
+  terminal bool
+  visits int
+  // The sum of playout results for the maximizing side, from 0 for a loss to
+  // 1 for a win.
+  value float64
+}
+
+// Searches a minimax.MiniMaxGame with Monte Carlo tree search using UCT.
+// Playouts make random moves until the game is won, lost or has no moves, so
+// GetScore only needs to be accurate for finished games. Longer playouts are
+// cut short and scored by the sign of GetScore.
+type MctsState struct {
+  game minimax.MiniMaxGame
+  minimizeStart bool
+  exploration float64
+  iterations int
+  budget time.Duration
+  // Zero means no limit.
+  maxPlayoutDepth int
+  random *rand.Rand
+  root *node
+  // The number of iterations in the last GetMove.
+  lastIterations int
+}
+
+func MakeState(game minimax.MiniMaxGame, minimize bool) *MctsState {
+  return &MctsState{
+    game, minimize, kDefaultExploration, 0, 0, 0, rand.New(rand.NewSource(1)),
+    nil, 0}
+}
+
+// Larger values try less visited moves more often.
+func (state *MctsState) SetExploration(exploration float64) {
+  state.exploration = exploration
+}
+
+// Stops each search after this many iterations. Zero means no limit.
+func (state *MctsState) SetIterations(iterations int) {
+  state.iterations = iterations
+}
+
+// Stops each search after this long. Zero means no limit.
+func (state *MctsState) SetBudget(budget time.Duration) {
+  state.budget = budget
+}
+
+// Cuts playouts short after this many moves. Zero means no limit.
+func (state *MctsState) SetMaxPlayoutDepth(depth int) {
+  state.maxPlayoutDepth = depth
+}
+
+// Playouts are random but repeatable for the same seed.
+func (state *MctsState) SetSeed(seed int64) {
+  state.random = rand.New(rand.NewSource(seed))
+}
+
+// Returns the number of iterations run by the last GetMove.
+func (state *MctsState) GetIterationCount() int {
+  return state.lastIterations
+}
+
+// Returns the most visited move, or nil if there are none. Reuses the part of
+// the last search's tree below the current position if it's within two moves
+// of the last root.
+func (state *MctsState) GetMove() minimax.MiniMaxMove {
+  state.reuseTree()
+  var deadline time.Time
+  if state.budget > 0 {
+    deadline = time.Now().Add(state.budget)
+  }
+  iterations := state.iterations
+  if iterations == 0 && state.budget == 0 {
+    iterations = kDefaultIterations
+  }
+  state.lastIterations = 0
+  for iterations == 0 || state.lastIterations < iterations {
+    if !deadline.IsZero() &&
+        state.lastIterations % kIterationsPerClockCheck == 0 &&
+        time.Now().After(deadline) {
+      break
+    }
+    state.iterate()
+    state.lastIterations++
+  }
+  var best *node
+  for _, child := range state.root.children {
+    if best == nil || child.visits > best.visits {
+      best = child
+    }
+  }
+  if best == nil {
+    if len(state.root.untried) > 0 {
+      return state.root.untried[0]
+    }
+    return nil
+  }
+  return best.move
+}
+
+func (state *MctsState) positionKey() uint64 {
+  if hasher, ok := state.game.(minimax.MiniMaxHasher); ok {
+    return hasher.Hash()
+  }
+  hash := fnv.New64a()
+  hash.Write([]byte(state.game.StringKey()))
+  return hash.Sum64()
+}
+
+func (state *MctsState) isTerminal() bool {
+  score := state.game.GetScore()
+  return score == minimax.MaxScore || score == minimax.MinScore
+}
+
+func (state *MctsState) makeNode(
+    move minimax.MiniMaxMove, parent *node, minimize bool) *node {
+  n := &node{move: move, parent: parent, minimize: minimize}
+  n.key = state.positionKey()
+  n.terminal = state.isTerminal()
+  if !n.terminal {
+    n.untried = state.game.GetAllMoves()
+    n.terminal = len(n.untried) == 0
+  }
+  return n
+}
+
+// Makes the current position the root, keeping what's known about it.
+func (state *MctsState) reuseTree() {
+  key := state.positionKey()
+  if root := state.root; root != nil {
+    if found := root.find(key, state.minimizeStart, 2); found != nil {
+      found.parent = nil
+      found.move = nil
+      state.root = found
+      return
+    }
+  }
+  state.root = state.makeNode(nil, nil, state.minimizeStart)
+}
+
+func (n *node) find(key uint64, minimize bool, depth int) *node {
+  if n.key == key && n.minimize == minimize {
+    return n
+  }
+  if depth == 0 {
+    return nil
+  }
+  for _, child := range n.children {
+    if found := child.find(key, minimize, depth - 1); found != nil {
+      return found
+    }
+  }
+  return nil
+}
+
+// Selects a leaf, expands it, plays out from it and backs up the result.
+func (state *MctsState) iterate() {
+  n := state.root
+  moves := 0
+  for len(n.untried) == 0 && len(n.children) > 0 {
+    n = state.selectChild(n)
+    state.game.MakeMove(n.move)
+    moves++
+  }
+  if len(n.untried) > 0 {
+    i := state.random.Intn(len(n.untried))
+    move := n.untried[i]
+    n.untried[i] = n.untried[len(n.untried) - 1]
+    n.untried = n.untried[:len(n.untried) - 1]
+    state.game.MakeMove(move)
+    moves++
+    child := state.makeNode(move, n, !n.minimize)
+    n.children = append(n.children, child)
+    n = child
+  }
+  result := state.playout()
+  for ; moves > 0; moves-- {
+    state.game.UndoMove()
+  }
+  for ; n != nil; n = n.parent {
+    n.visits++
+    n.value += result
+  }
+}
+
+// Picks the child with the best upper confidence bound for the side to move.
+func (state *MctsState) selectChild(n *node) *node {
+  var best *node
+  bestBound := math.Inf(-1)
+  logVisits := math.Log(float64(n.visits))
+  for _, child := range n.children {
+    mean := child.value / float64(child.visits)
+    if n.minimize {
+      mean = 1 - mean
+    }
+    bound := mean +
+        state.exploration * math.Sqrt(logVisits / float64(child.visits))
+    if bound > bestBound {
+      best = child
+      bestBound = bound
+    }
+  }
+  return best
+}
+
+// Plays random moves from the current position and returns the result for
+// the maximizing side. Leaves the game as it found it.
+func (state *MctsState) playout() float64 {
+  moves := 0
+  for state.maxPlayoutDepth == 0 || moves < state.maxPlayoutDepth {
+    if state.isTerminal() {
+      break
+    }
+    options := state.game.GetAllMoves()
+    if len(options) == 0 {
+      break
+    }
+    state.game.MakeMove(options[state.random.Intn(len(options))])
+    moves++
+  }
+  score := state.game.GetScore()
+  for ; moves > 0; moves-- {
+    state.game.UndoMove()
+  }
+  switch {
+    case score > 0: return 1
+    case score < 0: return 0
+  }
+  return 0.5
+}
@@ -0,0 +1,122 @@
+package mcts
+
+import (
+  "fmt"
+  "minimax"
+  "testing"
+  "time"
+)
+
+// Players take 1 to 3 stones in turn and whoever takes the last one wins. The
+// side to move loses if the stones left are a multiple of 4.
+type nimGame struct {
+  stones int
+  taken []int
+}
+
+func (game *nimGame) maximizerToMove() bool {
+  return len(game.taken) % 2 == 0
+}
+
+func (game *nimGame) GetAllMoves() []minimax.MiniMaxMove {
+  moves := []minimax.MiniMaxMove{}
+  for take := 1; take <= 3 && take <= game.stones; take++ {
+    moves = append(moves, take)
+  }
+  return moves
+}
+
+func (game *nimGame) GetScore() minimax.Score {
+  if game.stones > 0 {
+    return 0
+  }
+  // Whoever moved last won.
+  if game.maximizerToMove() {
+    return minimax.MinScore
+  }
+  return minimax.MaxScore
+}
+
+func (game *nimGame) MakeMove(move minimax.MiniMaxMove) {
+  game.stones -= move.(int)
+  game.taken = append(game.taken, move.(int))
+}
+
+func (game *nimGame) UndoMove() {
+  game.stones += game.taken[len(game.taken) - 1]
+  game.taken = game.taken[:len(game.taken) - 1]
+}
+
+func (game *nimGame) String() string {
+  return fmt.Sprint(game.stones)
+}
+
+func (game *nimGame) StringKey() string {
+  return fmt.Sprint(game.stones, game.maximizerToMove())
+}
+
+func TestGetMove_Nim(t *testing.T) {
+  for stones, want := range map[int]int{5: 1, 6: 2, 7: 3, 9: 1, 3: 3} {
+    game := &nimGame{stones, nil}
+    state := MakeState(game, false)
+    state.SetIterations(2000)
+
+    if got := state.GetMove(); got != want {
+      t.Errorf("stones: %v\ngot move: %v\nwant: %v", stones, got, want)
+    }
+    if game.stones != stones || len(game.taken) != 0 {
+      t.Errorf("stones: %v\nsearch changed the game: %v", stones, game)
+    }
+  }
+}
+
+func TestGetMove_SameSeedSameMove(t *testing.T) {
+  for seed := int64(0); seed < 5; seed++ {
+    first := MakeState(&nimGame{8, nil}, false)
+    first.SetIterations(100)
+    first.SetSeed(seed)
+    second := MakeState(&nimGame{8, nil}, false)
+    second.SetIterations(100)
+    second.SetSeed(seed)
+
+    if got, want := second.GetMove(), first.GetMove(); got != want {
+      t.Errorf("seed: %v\ngot move: %v\nwant: %v", seed, got, want)
+    }
+  }
+}
+
+func TestGetMove_Budget(t *testing.T) {
+  state := MakeState(&nimGame{1000, nil}, false)
+  state.SetBudget(50 * time.Millisecond)
+  start := time.Now()
+
+  state.GetMove()
+
+  if elapsed := time.Since(start); elapsed > time.Second {
+    t.Errorf("got elapsed: %v\nwant about 50ms", elapsed)
+  }
+  if state.GetIterationCount() == 0 {
+    t.Errorf("got no iterations")
+  }
+}
+
+func TestGetMove_ReusesTree(t *testing.T) {
+  game := &nimGame{9, nil}
+  state := MakeState(game, false)
+  state.SetIterations(1000)
+  game.MakeMove(state.GetMove())
+  game.MakeMove(1)
+  root := state.root.find(state.positionKey(), false, 2)
+  if root == nil {
+    t.Fatalf("game: %v\nposition isn't in the tree", game)
+  }
+  visits := root.visits
+
+  state.GetMove()
+
+  if state.root != root || root.visits != visits + 1000 {
+    t.Errorf(
+        "game: %v\ngot root visits: %v\nwant: %v", game, state.root.visits,
+        visits + 1000)
+  }
+}
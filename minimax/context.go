@@ -0,0 +1,39 @@
+package minimax
+
+import (
+  "context"
+  "sync/atomic"
+)
+
+// Searches like IterativeDeepening until maxDepth or until ctx is done. Also
+// returns false if the search was stopped early, in which case the move is the
+// best found so far.
+func (state *State[M]) GetMoveContext(ctx context.Context) (M, bool) {
+  result := state.Search(ctx)
+  return result.Move, result.Complete
+}
+
+// Like GetMoveContext, but says more about the search.
+func (state *State[M]) Search(ctx context.Context) *Result[M] {
+  if result := state.finishPondering(ctx); result != nil {
+    return result
+  }
+  stop := int32(0)
+  if ctx.Err() != nil {
+    // The watcher below might not run before the search ends.
+    stop = 1
+  }
+  state.stop = &stop
+  done := make(chan struct{})
+  defer func() {
+    close(done)
+    state.stop = nil
+  }()
+  go func() {
+    select {
+      case <-ctx.Done(): atomic.StoreInt32(&stop, 1)
+      case <-done:
+    }
+  }()
+  return state.iterativeDeepening(0, 0)
+}
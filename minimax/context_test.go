@@ -0,0 +1,25 @@
+package minimax
+
+import (
+  "context"
+  "testing"
+)
+
+func TestSearch_AlreadyCancelled(t *testing.T) {
+  game := makeScoredPathGame()
+  state := MakeState(game, false, 3)
+  ctx, cancel := context.WithCancel(context.Background())
+  cancel()
+
+  result := state.Search(ctx)
+
+  if result.Complete {
+    t.Errorf("got complete after cancel")
+  }
+  if !result.HasMove {
+    t.Errorf("got no move")
+  }
+  if nodes := state.GetNodeCount(); nodes > 1 {
+    t.Errorf("got %v nodes, want at most 1", nodes)
+  }
+}
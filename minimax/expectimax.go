@@ -0,0 +1,171 @@
+package minimax
+
+import "math"
+
+// Less probability than this left over is taken to be rounding error.
+const kProbabilityEpsilon = 1e-9
+
+// One way a chance node can turn out.
+type Outcome[M any] struct {
+  Move M
+  Probability float64
+}
+
+type ChanceOutcome = Outcome[MiniMaxMove]
+
+// Games with dice or cards can implement this to have chance nodes scored by
+// their expected value. Outcomes are made with MakeMove and UndoMove like
+// other moves, but don't count towards the search depth or change the side to
+// move, so a roll should come right before the move it's for. Scores are
+// averaged in whole units, so scale them up if fractions matter. The root
+// shouldn't be a chance node.
+type ChanceGame[M any] interface {
+  IsChanceNode() bool
+  // Probabilities should add up to 1.
+  GetOutcomes() []Outcome[M]
+}
+
+type MiniMaxChanceGame = ChanceGame[MiniMaxMove]
+
+// Games with chance nodes can implement this to say that no position reachable
+// from the current one scores outside the returned bounds, which lets
+// alpha-beta prune at chance nodes too. Tighter bounds prune more. Wins count,
+// so they should be MinScore and MaxScore once the game can end.
+type MiniMaxScoreBounder interface {
+  GetScoreBounds() (Score, Score)
+}
+
+func (state *State[M]) scoreBounds() (Score, Score) {
+  if bounder, ok := state.game.(MiniMaxScoreBounder); ok {
+    return bounder.GetScoreBounds()
+  }
+  return MinScore, MaxScore
+}
+
+// Scores the chance node at the current position by the expected value of its
+// outcomes. Prunes like Star1: after each outcome the bounds say how well the
+// rest could do, so each outcome is searched with the window it needs to
+// change the result, and the rest are skipped once it's outside the window.
+func (state *State[M]) runChance(
+    chance ChanceGame[M], minimize bool, ply int, depth int, alpha Score,
+    beta Score) Score {
+  lower, upper := state.scoreBounds()
+  outcomes := chance.GetOutcomes()
+  // The expected value of the outcomes searched so far.
+  sum := 0.0
+  remaining := 1.0
+  searched := 0
+  var first Score
+  allEqual := true
+  for _, outcome := range outcomes {
+    probability := outcome.Probability
+    if probability <= 0 {
+      continue
+    }
+    if state.checkLimits() {
+      break
+    }
+    outcomeAlpha, outcomeBeta := MinScore, MaxScore
+    if state.alphaBeta {
+      // The scores this outcome needs to push the expected value out of the
+      // window, however the rest turn out.
+      rest := leftOver(remaining - probability)
+      // With no bound on how well the rest could do, only the last outcome
+      // has edges.
+      alphaEdge, betaEdge := math.Inf(-1), math.Inf(1)
+      if rest == 0 || upper != MaxScore {
+        alphaEdge = (float64(alpha) - sum - rest * float64(upper)) / probability
+      }
+      if rest == 0 || lower != MinScore {
+        betaEdge = (float64(beta) - sum - rest * float64(lower)) / probability
+      }
+      if alphaEdge >= float64(upper) {
+        return minScore(
+            scoreFromFloat(math.Ceil(sum + remaining * float64(upper))),
+            alpha)
+      }
+      if betaEdge <= float64(lower) {
+        return maxScore(
+            scoreFromFloat(math.Floor(sum + remaining * float64(lower))),
+            beta)
+      }
+      if alphaEdge > float64(lower) {
+        outcomeAlpha = scoreFromFloat(math.Floor(alphaEdge))
+      }
+      if betaEdge < float64(upper) {
+        outcomeBeta = scoreFromFloat(math.Ceil(betaEdge))
+      }
+    }
+    remaining = leftOver(remaining - probability)
+    score := state.tryOutcome(
+        minimize, ply, depth, outcome.Move, outcomeAlpha, outcomeBeta)
+    if searched == 0 {
+      first = score
+    }
+    searched++
+    allEqual = allEqual && score == first
+    sum += probability * float64(score)
+    if outcomeAlpha != MinScore && score <= outcomeAlpha {
+      // The rest can't make up for it.
+      return minScore(
+          scoreFromFloat(math.Ceil(sum + remaining * float64(upper))), alpha)
+    }
+    if outcomeBeta != MaxScore && score >= outcomeBeta {
+      return maxScore(
+          scoreFromFloat(math.Floor(sum + remaining * float64(lower))), beta)
+    }
+  }
+  if allEqual {
+    // Exact, even for wins too close to MaxScore to average in floats.
+    return first
+  }
+  return scoreFromFloat(math.Round(sum))
+}
+
+func (state *State[M]) tryOutcome(
+    minimize bool, ply int, depth int, move M, alpha Score,
+    beta Score) Score {
+  state.game.MakeMove(move)
+  state.nodes++
+  state.pushTrace(ply + 1, move, minimize, depth, alpha, beta)
+  score := state.game.GetScore()
+  if score == MaxScore || score == MinScore {
+    score = mateScore(score, ply + 1)
+  } else {
+    _, score = state.run(minimize, ply + 1, depth, alpha, beta)
+  }
+  state.popTrace(ply + 1, score)
+  state.game.UndoMove()
+  return score
+}
+
+func leftOver(probability float64) float64 {
+  if probability < kProbabilityEpsilon {
+    return 0
+  }
+  return probability
+}
+
+func scoreFromFloat(score float64) Score {
+  if score >= float64(MaxScore) {
+    return MaxScore
+  }
+  if score <= float64(MinScore) {
+    return MinScore
+  }
+  return Score(score)
+}
+
+func minScore(a Score, b Score) Score {
+  if a < b {
+    return a
+  }
+  return b
+}
+
+func maxScore(a Score, b Score) Score {
+  if a > b {
+    return a
+  }
+  return b
+}
@@ -0,0 +1,210 @@
+package minimax
+
+import (
+  "fmt"
+  "testing"
+)
+
+// Each side in turn either takes safe points or rolls a die. Points count for
+// the side that took them, and the score is the total.
+type diceGame struct {
+  rounds int
+  safe int
+  // Safe moves, rolls and the die faces they came up with, in order.
+  events []diceEvent
+}
+
+type diceEvent struct {
+  roll bool
+  // Zero until rolled.
+  face int
+}
+
+// Rolls 7 on average.
+var diceFaces = []int{2, 4, 6, 8, 10, 12}
+
+// Points for the maximizing side count up, for the other side down.
+func sign(move int) int {
+  if move % 2 == 0 {
+    return 1
+  }
+  return -1
+}
+
+func (game *diceGame) IsChanceNode() bool {
+  n := len(game.events)
+  return n > 0 && game.events[n - 1].roll && game.events[n - 1].face == 0
+}
+
+func (game *diceGame) GetOutcomes() []ChanceOutcome {
+  outcomes := make([]ChanceOutcome, len(diceFaces))
+  for i, face := range diceFaces {
+    outcomes[i] = ChanceOutcome{face, 1.0 / float64(len(diceFaces))}
+  }
+  return outcomes
+}
+
+// The score can change by at most 12 for each move left.
+func (game *diceGame) GetScoreBounds() (Score, Score) {
+  moves := game.rounds - len(game.events)
+  if game.IsChanceNode() {
+    moves++
+  }
+  score := game.GetScore()
+  return score - Score(12 * moves), score + Score(12 * moves)
+}
+
+func (game *diceGame) GetAllMoves() []MiniMaxMove {
+  if len(game.events) >= game.rounds {
+    return nil
+  }
+  return []MiniMaxMove{"safe", "roll"}
+}
+
+func (game *diceGame) GetScore() Score {
+  score := 0
+  for move, event := range game.events {
+    if event.roll {
+      score += sign(move) * event.face
+    } else {
+      score += sign(move) * game.safe
+    }
+  }
+  return Score(score)
+}
+
+func (game *diceGame) MakeMove(move MiniMaxMove) {
+  switch move := move.(type) {
+    case int:
+      game.events[len(game.events) - 1].face = move
+    case string:
+      game.events = append(game.events, diceEvent{move == "roll", 0})
+  }
+}
+
+func (game *diceGame) UndoMove() {
+  last := &game.events[len(game.events) - 1]
+  if last.roll && last.face != 0 {
+    last.face = 0
+  } else {
+    game.events = game.events[:len(game.events) - 1]
+  }
+}
+
+func (game *diceGame) String() string {
+  return fmt.Sprint(game.events)
+}
+
+func (game *diceGame) StringKey() string {
+  return game.String()
+}
+
+func TestExpectimax_RollsForMoreOnAverage(t *testing.T) {
+  for _, minimize := range []bool{false, true} {
+    game := &diceGame{1, 6, nil}
+    if minimize {
+      game.MakeMove("safe")
+      game.rounds = 2
+    }
+    state := MakeState(game, minimize, 2)
+
+    move, score := state.run(minimize, 0, 2, MinScore, MaxScore)
+
+    want := Score(7)
+    if minimize {
+      want = 6 - 7
+    }
+    if move == nil || *move != "roll" || score != want {
+      t.Errorf(
+          "minimize: %v\ngot move: %v score: %v\nwant: roll %v", minimize,
+          move, score, want)
+    }
+  }
+}
+
+func TestExpectimax_PruningKeepsScore(t *testing.T) {
+  // Rolling is worse on average, but sometimes better.
+  game := &diceGame{4, 8, nil}
+  plain := MakeState(game, false, 8)
+  plain.SetAlphaBeta(false)
+  pruned := MakeState(game, false, 8)
+
+  wantMove, want := plain.run(false, 0, 8, MinScore, MaxScore)
+  gotMove, got := pruned.run(false, 0, 8, MinScore, MaxScore)
+
+  if *gotMove != *wantMove || got != want {
+    t.Errorf(
+        "got move: %v score: %v\nwant: %v %v", *gotMove, got, *wantMove, want)
+  }
+  if pruned.GetNodeCount() >= plain.GetNodeCount() {
+    t.Errorf(
+        "got nodes: %v\nwant fewer than: %v", pruned.GetNodeCount(),
+        plain.GetNodeCount())
+  }
+}
+
+// diceGame rolling two dice, with all 36 rolls as outcomes, and no score
+// bounds.
+type twoDiceGame struct {
+  game *diceGame
+}
+
+func (game twoDiceGame) IsChanceNode() bool {
+  return game.game.IsChanceNode()
+}
+
+func (game twoDiceGame) GetOutcomes() []ChanceOutcome {
+  outcomes := []ChanceOutcome{}
+  for first := 1; first <= 6; first++ {
+    for second := 1; second <= 6; second++ {
+      outcomes = append(outcomes, ChanceOutcome{first + second, 1.0 / 36})
+    }
+  }
+  return outcomes
+}
+
+func (game twoDiceGame) GetAllMoves() []MiniMaxMove {
+  return game.game.GetAllMoves()
+}
+
+func (game twoDiceGame) GetScore() Score {
+  return game.game.GetScore()
+}
+
+func (game twoDiceGame) MakeMove(move MiniMaxMove) {
+  game.game.MakeMove(move)
+}
+
+func (game twoDiceGame) UndoMove() {
+  game.game.UndoMove()
+}
+
+func (game twoDiceGame) String() string {
+  return game.game.String()
+}
+
+func (game twoDiceGame) StringKey() string {
+  return game.game.StringKey()
+}
+
+// Rounding errors in the probability left over once every outcome is searched
+// mustn't move the window.
+func TestExpectimax_PruningWithoutBounds(t *testing.T) {
+  for rounds := 1; rounds <= 3; rounds++ {
+    for safe := 4; safe <= 10; safe++ {
+      game := twoDiceGame{&diceGame{rounds, safe, nil}}
+      plain := MakeState(game, false, 2 * rounds)
+      plain.SetAlphaBeta(false)
+      pruned := MakeState(game, false, 2 * rounds)
+
+      wantMove, want := plain.run(false, 0, 2 * rounds, MinScore, MaxScore)
+      gotMove, got := pruned.run(false, 0, 2 * rounds, MinScore, MaxScore)
+
+      if *gotMove != *wantMove || got != want {
+        t.Errorf(
+            "rounds: %v safe: %v\ngot move: %v score: %v\nwant: %v %v",
+            rounds, safe, *gotMove, got, *wantMove, want)
+      }
+    }
+  }
+}
@@ -1,3 +1,3 @@
 module minimax
 
-go 1.16
+go 1.18
@@ -0,0 +1,82 @@
+package minimax
+
+import (
+  "sync/atomic"
+  "time"
+)
+
+// Checking the clock is slow compared to visiting a node, so only do it every
+// this many nodes.
+const kNodesPerClockCheck = 1024
+
+// Searches depth 1, 2, 3... up to maxDepth until budget or nodeLimit runs out
+// and returns the best move from the last completed depth. A zero budget or
+// nodeLimit means no limit. Each depth searches the previous depth's best move
+// first.
+func (state *State[M]) IterativeDeepening(
+    budget time.Duration, nodeLimit int) M {
+  return state.iterativeDeepening(budget, nodeLimit).Move
+}
+
+func (state *State[M]) iterativeDeepening(
+    budget time.Duration, nodeLimit int) *Result[M] {
+  start := time.Now()
+  state.startSearch(budget, nodeLimit)
+  result := &Result[M]{}
+  // Scores usually stay close to the position's own score and to the
+  // previous depth's.
+  guess := state.game.GetScore()
+  for depth := 1; depth <= state.maxDepth; depth++ {
+    // The table holds the previous depth's best move for the root, so it's
+    // searched first.
+    move, score := state.runDriver(depth, guess)
+    if state.aborted {
+      if !result.HasMove {
+        // Not even depth 1 finished. Better than nothing.
+        result.setMove(move)
+      }
+      break
+    }
+    result.setMove(move)
+    result.Score = score
+    guess = score
+    result.Depth = depth
+    if move == nil {
+      // Game over
+      break
+    }
+    result.PrincipalVariation = state.principalVariation(*move, depth)
+    state.fillStats(result, start)
+    if state.progress != nil {
+      progress := *result
+      state.progress(&progress)
+    }
+  }
+  if !result.HasMove && result.Depth == 0 {
+    if moves := state.game.GetAllMoves(); len(moves) > 0 {
+      result.setMove(&moves[0])
+    }
+  }
+  state.fillStats(result, start)
+  result.Complete = !state.aborted
+  return result
+}
+
+// Returns true if the search should stop.
+func (state *State[M]) checkLimits() bool {
+  if state.aborted {
+    return true
+  }
+  if state.stop != nil && atomic.LoadInt32(state.stop) != 0 {
+    state.aborted = true
+    return true
+  }
+  if state.nodeLimit > 0 && state.nodes >= state.nodeLimit {
+    state.aborted = true
+  } else if !state.deadline.IsZero() &&
+      state.nodes % kNodesPerClockCheck == 0 &&
+      time.Now().After(state.deadline) {
+    state.aborted = true
+  }
+  return state.aborted
+}
@@ -0,0 +1,65 @@
+package minimax
+
+import (
+  "sync"
+  "sync/atomic"
+)
+
+// Searches the root with this many goroutines, each on its own copy of the
+// game, all sharing the state's table. Only used if the game implements
+// MiniMaxCloner. Helpers search the same position, every other one a move
+// deeper, each starting from a different root move, and fill the table with
+// results the main search then reuses. With one thread the search is the
+// plain sequential one.
+func (state *State[M]) SetLazySmpThreads(threads int) {
+  state.lazySmpThreads = threads
+}
+
+func (state *State[M]) runLazySmp(
+    cloner Cloner[M], depth int, alpha Score,
+    beta Score) (*M, Score) {
+  stop := int32(0)
+  helpers := state.makeWorkers(cloner, state.lazySmpThreads - 1, &stop)
+  var wait sync.WaitGroup
+  for i, helper := range helpers {
+    wait.Add(1)
+    go func(helper *State[M], offset int, depth int) {
+      defer wait.Done()
+      helper.runHelper(offset, depth)
+    }(helper, i + 1, depth + i % 2)
+  }
+  move, score := state.run(state.minimizeStart, 0, depth, alpha, beta)
+  // The helpers only exist to help the main search.
+  atomic.StoreInt32(&stop, 1)
+  wait.Wait()
+  for _, helper := range helpers {
+    state.nodes += helper.nodes
+    state.tableHits += helper.tableHits
+  }
+  return move, score
+}
+
+// Like run at the root, but starts offset moves into the usual order so that
+// helpers don't all repeat the main search.
+func (state *State[M]) runHelper(offset int, depth int) {
+  minimize := state.minimizeStart
+  chance, ok := state.game.(ChanceGame[M])
+  moves := state.game.GetAllMoves()
+  if (ok && chance.IsChanceNode()) || len(moves) == 0 {
+    state.run(minimize, 0, depth, MinScore, MaxScore)
+    return
+  }
+  hash, key := state.positionKey()
+  entry, ok := state.table.lookup(hash, key, minimize)
+  state.orderMoves(moves, 0, entry.bestMove, ok && entry.hasMove)
+  offset %= len(moves)
+  rotated := append(append([]M{}, moves[offset:]...), moves[:offset]...)
+  move, score := state.runMoves(
+      minimize, 0, depth, MinScore, MaxScore, rotated)
+  if !state.aborted {
+    bestKey, hasMove := state.keyOf(move)
+    state.table.store(
+        hash, key, minimize, depth, boundFor(score, MinScore, MaxScore),
+        score, bestKey, hasMove)
+  }
+}
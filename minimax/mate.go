@@ -0,0 +1,79 @@
+package minimax
+
+import "fmt"
+
+// Scores this close to MaxScore or MinScore are wins found by the search.
+// Deeper wins score as if they were this deep.
+const kMaxMatePly = 1 << 16
+
+// Games score wins as MaxScore or MinScore. The search takes off the number
+// of moves from the root, so that it prefers the fastest win and the slowest
+// loss.
+func mateScore(score Score, ply int) Score {
+  if ply > kMaxMatePly {
+    ply = kMaxMatePly
+  }
+  switch score {
+    case MaxScore: return MaxScore - Score(ply)
+    case MinScore: return MinScore + Score(ply)
+  }
+  return score
+}
+
+// Returns true if score is a win for either side.
+func IsMate(score Score) bool {
+  return score >= MaxScore - kMaxMatePly || score <= MinScore + kMaxMatePly
+}
+
+// Returns the number of moves from the root to the win, counting both sides'
+// moves, or -1 if score isn't a win.
+func MatePlies(score Score) int {
+  if !IsMate(score) {
+    return -1
+  }
+  if score > 0 {
+    return int(MaxScore - score)
+  }
+  return int(score - MinScore)
+}
+
+// Returns N for "mate in N": the number of moves the winning side makes to
+// win. Positive if the maximizing side wins and negative if the minimizing
+// side does. Returns 0 if score isn't a win or the game is already won.
+func MateIn(score Score) int {
+  moves := (MatePlies(score) + 1) / 2
+  if score < 0 {
+    return -moves
+  }
+  return moves
+}
+
+// Wins are stored counting moves from the position rather than from the
+// root, since the position may be reached at a different ply next time.
+func scoreToTable(score Score, ply int) Score {
+  if !IsMate(score) {
+    return score
+  }
+  if score > 0 {
+    return addScores(score, Score(ply))
+  }
+  return addScores(score, -Score(ply))
+}
+
+func scoreFromTable(score Score, ply int) Score {
+  if !IsMate(score) {
+    return score
+  }
+  if score > 0 {
+    return score - Score(ply)
+  }
+  return score + Score(ply)
+}
+
+// Like fmt.Sprint(score), but wins read as e.g. "mate 3" or "mate -2".
+func ScoreString(score Score) string {
+  if IsMate(score) {
+    return fmt.Sprintf("mate %v", MateIn(score))
+  }
+  return fmt.Sprint(score)
+}
@@ -0,0 +1,54 @@
+package minimax
+
+import "testing"
+
+func TestMateIn(t *testing.T) {
+  for _, test := range []struct {
+    score Score
+    want int
+  }{
+    {mateScore(MaxScore, 1), 1},
+    {mateScore(MaxScore, 3), 2},
+    {mateScore(MinScore, 2), -1},
+    {mateScore(MinScore, 4), -2},
+    {0, 0},
+    {39, 0},
+  } {
+    if got := MateIn(test.score); got != test.want {
+      t.Errorf("score: %v\ngot mate in: %v\nwant: %v", test.score, got,
+          test.want)
+    }
+  }
+}
+
+func TestMateScore_PrefersFasterWins(t *testing.T) {
+  if mateScore(MaxScore, 1) <= mateScore(MaxScore, 3) {
+    t.Errorf("want faster wins to score higher")
+  }
+  if mateScore(MinScore, 1) >= mateScore(MinScore, 3) {
+    t.Errorf("want slower losses to score higher")
+  }
+}
+
+func TestScoreToTable(t *testing.T) {
+  // A win 5 moves from the root found at ply 2 is a win in 3 from there, so
+  // it's a win in 4 if the position comes up again at ply 1.
+  stored := scoreToTable(mateScore(MaxScore, 5), 2)
+
+  if got, want := scoreFromTable(stored, 1), mateScore(MaxScore, 4);
+      got != want {
+    t.Errorf("got score: %v\nwant: %v", got, want)
+  }
+  if got := scoreFromTable(scoreToTable(7, 2), 1); got != 7 {
+    t.Errorf("got score: %v\nwant: 7", got)
+  }
+}
+
+func TestScoreString(t *testing.T) {
+  if got := ScoreString(mateScore(MinScore, 4)); got != "mate -2" {
+    t.Errorf("got: %v\nwant: mate -2", got)
+  }
+  if got := ScoreString(3); got != "3" {
+    t.Errorf("got: %v\nwant: 3", got)
+  }
+}
@@ -1,5 +1,10 @@
 package minimax
 
+import (
+  "math/rand"
+  "time"
+)
+
 type Score int
 
 const (
@@ -11,72 +16,341 @@ const kMaxUint = ^uint(0)
 
 type MiniMaxMove interface { }
 
-type MiniMaxGame interface {
-  GetAllMoves() []MiniMaxMove
+// A game whose moves have type M. Searching a Game with a concrete move type,
+// e.g. a struct or a pointer to one, saves converting every move to and from
+// MiniMaxMove. Games with moves that aren't pointers should implement
+// MoveHasher.
+type Game[M any] interface {
+  GetAllMoves() []M
   GetScore() Score
-  MakeMove(move MiniMaxMove)
+  MakeMove(move M)
   UndoMove()
   String() string
   StringKey() string
 }
 
-type MiniMaxState struct {
-  game MiniMaxGame
+type MiniMaxGame = Game[MiniMaxMove]
+
+// Games can implement this to key the transposition table more cheaply than
+// with StringKey. Equal positions must hash the same. Hashes should differ for
+// different sides to move, but needn't since the table also keys on it.
+type MiniMaxHasher interface {
+  Hash() uint64
+}
+
+// Searches a Game[M]. MiniMaxState searches a MiniMaxGame.
+type State[M any] struct {
+  game Game[M]
   minimizeStart bool
   maxDepth int
-  visited map[string]Score
+  table *TranspositionTable
+  alphaBeta bool
+  quiescence bool
+  nullMove bool
+  lateMoveReductions bool
+  futilityPruning bool
+  futilityMargin Score
+  principalVariationSearch bool
+  mtdf bool
+  // Zero for no aspiration windows.
+  aspirationWindow Score
+  // True while searching the position after a null move.
+  afterNullMove bool
+  nodes int
+  tableHits int
+  progress func(result *Result[M])
+  // Move ordering heuristics, indexed by ply and by move key.
+  killers [][]uint64
+  history map[uint64]int
+  // Limits for the current search. Zero means no limit.
+  deadline time.Time
+  nodeLimit int
+  aborted bool
+  rootWorkers int
+  lazySmpThreads int
+  // Set by another goroutine to stop this search.
+  stop *int32
+  // nil unless root moves are picked at random.
+  random *rand.Rand
+  temperature float64
+  // Zero unless tracing. tracePath runs from the root to the position being
+  // searched.
+  traceDepth int
+  traceRoot *TraceNode
+  tracePath []*TraceNode
+  // nil unless pondering.
+  ponder *ponder[M]
 }
 
+type MiniMaxState = State[MiniMaxMove]
+
 func MakeState(game MiniMaxGame, minimize bool, maxDepth int) *MiniMaxState {
-  return &MiniMaxState{game, minimize, maxDepth, make(map[string]Score)}
+  return MakeGameState[MiniMaxMove](game, minimize, maxDepth)
+}
+
+func MakeGameState[M any](
+    game Game[M], minimize bool, maxDepth int) *State[M] {
+  return &State[M]{
+    game: game,
+    minimizeStart: minimize,
+    maxDepth: maxDepth,
+    table: MakeTranspositionTable(kDefaultTableEntries),
+    alphaBeta: true,
+    quiescence: true,
+    futilityMargin: kDefaultFutilityMargin,
+    history: make(map[uint64]int),
+    rootWorkers: 1,
+    lazySmpThreads: 1,
+  }
 }
 
 func MiniMax(game MiniMaxGame, minimize bool, maxDepth int) MiniMaxMove {
-  state := MakeState(game, minimize, maxDepth)
-  move, _ := state.run(minimize, 1)
-  return move
+  return MakeState(game, minimize, maxDepth).GetMove()
 }
 
-func (state *MiniMaxState) GetMove() MiniMaxMove {
-  state.visited = make(map[string]Score)
-  move, _ := state.run(state.minimizeStart, 1)
-  return move
+// Alpha-beta pruning is on by default. Turning it off scores every child of
+// every node, which is only useful to compare against.
+func (state *State[M]) SetAlphaBeta(enabled bool) {
+  state.alphaBeta = enabled
 }
 
-func (state *MiniMaxState) run(minimize bool, depth int) (MiniMaxMove, Score) {
+// Replaces the table, e.g. to change its size or to share one between states
+// searching the same game.
+func (state *State[M]) SetTable(table *TranspositionTable) {
+  state.table = table
+}
+
+func (state *State[M]) GetTable() *TranspositionTable {
+  return state.table
+}
+
+// Returns the number of positions visited by the last GetMove.
+func (state *State[M]) GetNodeCount() int {
+  return state.nodes
+}
+
+// Returns the zero value if there are no moves.
+func (state *State[M]) GetMove() M {
+  state.startSearch(0, 0)
+  var move *M
+  if state.mtdf {
+    move, _ = state.runDriver(state.maxDepth, state.game.GetScore())
+  } else {
+    move, _ = state.runRoot(state.maxDepth, MinScore, MaxScore)
+  }
+  if move == nil {
+    var none M
+    return none
+  }
+  return *move
+}
+
+// Searches the root depth moves ahead with the given window, which must be
+// the full one when picking moves at random. Moves are returned by pointer so
+// that nil can mean there are none.
+func (state *State[M]) runRoot(
+    depth int, alpha Score, beta Score) (*M, Score) {
+  state.startTrace(depth, alpha, beta)
+  move, score := state.searchRoot(depth, alpha, beta)
+  state.popTrace(0, score)
+  return move, score
+}
+
+func (state *State[M]) searchRoot(
+    depth int, alpha Score, beta Score) (*M, Score) {
+  if state.isRandom() {
+    return state.runSampled(depth)
+  }
+  if cloner, ok := state.game.(Cloner[M]); ok {
+    if state.lazySmpThreads > 1 {
+      return state.runLazySmp(cloner, depth, alpha, beta)
+    }
+    if state.rootWorkers > 1 {
+      return state.runParallel(cloner, depth)
+    }
+  }
+  return state.run(state.minimizeStart, 0, depth, alpha, beta)
+}
+
+// Returns the game's hash if it has one. Otherwise hashes StringKey and
+// returns it too so that collisions can be told apart.
+func (state *State[M]) positionKey() (uint64, string) {
+  if hasher, ok := state.game.(MiniMaxHasher); ok {
+    return hasher.Hash(), ""
+  }
+  key := state.game.StringKey()
+  return hashString(key), key
+}
+
+func (state *State[M]) startSearch(budget time.Duration, nodeLimit int) {
+  state.StopPondering()
+  state.nodes = 0
+  state.tableHits = 0
+  state.deadline = time.Time{}
+  if budget > 0 {
+    state.deadline = time.Now().Add(budget)
+  }
+  state.nodeLimit = nodeLimit
+  state.aborted = false
+  state.table.newSearch()
+  state.resetOrdering()
+}
+
+// Searches the current position depth moves ahead, where ply is the number of
+// moves made since the root. The returned score is exact if it lies strictly
+// between alpha and beta. Otherwise it is only a bound: at most alpha or at
+// least beta.
+func (state *State[M]) run(
+    minimize bool, ply int, depth int, alpha Score,
+    beta Score) (*M, Score) {
+  afterNullMove := state.afterNullMove
+  state.afterNullMove = false
+  hash, key := state.positionKey()
+  var tableMove uint64
+  hasTableMove := false
+  if entry, ok := state.table.lookup(hash, key, minimize); ok {
+    entry.score = scoreFromTable(entry.score, ply)
+    // Always search the root so there is a move to return. Deeper down only
+    // the score is needed.
+    if ply > 0 && entry.cutsOff(depth, alpha, beta) {
+      state.tableHits++
+      if node := state.currentTrace(ply); node != nil {
+        node.TableHit = true
+      }
+      return nil, entry.score
+    }
+    tableMove, hasTableMove = entry.bestMove, entry.hasMove
+  }
+  if chance, ok := state.game.(ChanceGame[M]);
+      ok && chance.IsChanceNode() {
+    score := state.runChance(chance, minimize, ply, depth, alpha, beta)
+    if !state.aborted {
+      state.table.store(
+          hash, key, minimize, depth, boundFor(score, alpha, beta),
+          scoreToTable(score, ply), 0, false)
+    }
+    return nil, score
+  }
+  if !afterNullMove {
+    if pruned, score := state.tryNullMove(minimize, ply, depth, alpha, beta);
+        pruned {
+      if node := state.currentTrace(ply); node != nil {
+        node.NullMovePruned = true
+      }
+      return nil, score
+    }
+  }
   moves := state.game.GetAllMoves()
   if len(moves) == 0 {
-    return nil, state.game.GetScore()
-  }
-  bestMove, bestScore :=
-      state.tryMove(minimize, depth, moves[0])
-  for i, n := 1, len(moves); i < n; i++ {
-    move, score := state.tryMove(minimize, depth, moves[i])
-    if (minimize && score < bestScore) || (!minimize && score > bestScore) {
-      bestMove = move
+    return nil, mateScore(state.game.GetScore(), ply)
+  }
+  state.orderMoves(moves, ply, tableMove, hasTableMove)
+  move, score := state.runMoves(minimize, ply, depth, alpha, beta, moves)
+  if !state.aborted {
+    bestKey, hasMove := state.keyOf(move)
+    state.table.store(
+        hash, key, minimize, depth, boundFor(score, alpha, beta),
+        scoreToTable(score, ply), bestKey, hasMove)
+  }
+  return move, score
+}
+
+// Like run, but searches the given moves in the given order. If the search is
+// aborted the result is meaningless.
+func (state *State[M]) runMoves(
+    minimize bool, ply int, depth int, alpha Score, beta Score,
+    moves []M) (*M, Score) {
+  var bestMove *M
+  var bestScore Score
+  canPrune, futileScore := state.futileScore(minimize, ply, depth)
+  for i, move := range moves {
+    if state.checkLimits() {
+      break
+    }
+    var score Score
+    if canPrune && state.isQuiet(ply, move) &&
+        ((minimize && futileScore >= beta) ||
+            (!minimize && futileScore <= alpha)) {
+      // Can't reach the window, so searching it wouldn't change the result.
+      score = futileScore
+    } else {
+      score = state.searchMove(minimize, ply, depth, i, move, alpha, beta)
+    }
+    if i == 0 || isBetter(minimize, score, bestScore) {
+      bestMove = &moves[i]
       bestScore = score
     }
+    if !state.alphaBeta {
+      continue
+    }
+    if minimize && score < beta {
+      beta = score
+    } else if !minimize && score > alpha {
+      alpha = score
+    }
+    if alpha >= beta {
+      // The other side already has a better option elsewhere.
+      state.recordCutoff(ply, depth, move)
+      if node := state.currentTrace(ply); node != nil {
+        node.Cutoff = true
+      }
+      break
+    }
   }
   return bestMove, bestScore
 }
 
-func (state *MiniMaxState) tryMove(
-    minimize bool, depth int, move MiniMaxMove) (MiniMaxMove, Score) {
-  state.game.MakeMove(move)
-  key := state.game.StringKey()
-  if score, ok := state.visited[key]; ok {
-    // Already visited
-    state.game.UndoMove()
-    return move, score
+// Like tryMove, but first tries cheaper searches that may be enough to tell
+// that the move at index in the order isn't better than the ones before it.
+func (state *State[M]) searchMove(
+    minimize bool, ply int, depth int, index int, move M, alpha Score,
+    beta Score) Score {
+  if state.reducesLateMove(ply, depth, index, move) {
+    reduced := depth - kLateMoveReduction
+    score, failed := state.tryNullWindow(
+        minimize, ply, reduced, move, alpha, beta)
+    if failed {
+      return score
+    }
+  }
+  if state.principalVariationSearch && state.alphaBeta && index > 0 {
+    score, failed := state.tryNullWindow(
+        minimize, ply, depth, move, alpha, beta)
+    if failed || !isExact(score, alpha, beta) {
+      return score
+    }
   }
+  return state.tryMove(minimize, ply, depth, move, alpha, beta)
+}
+
+func (state *State[M]) tryMove(
+    minimize bool, ply int, depth int, move M, alpha Score,
+    beta Score) Score {
+  state.game.MakeMove(move)
+  state.nodes++
+  state.pushTrace(ply + 1, move, !minimize, depth - 1, alpha, beta)
   score := state.game.GetScore()
-  // Mark visited before recursive calls
-  state.visited[key] = score
-  if score != MaxScore && score != MinScore && depth != state.maxDepth {
-    _, score = state.run(!minimize, depth + 1)
-    // Update score if we made recursive calls
-    state.visited[key] = score
+  if score == MaxScore || score == MinScore {
+    score = mateScore(score, ply + 1)
+  } else if depth > 1 {
+    _, score = state.run(!minimize, ply + 1, depth - 1, alpha, beta)
+  } else if noisy, ok := state.game.(NoisyMover[M]);
+      ok && state.quiescence {
+    score = state.quiesce(noisy, !minimize, ply + 1, 0, alpha, beta, score)
   }
+  state.popTrace(ply + 1, score)
   state.game.UndoMove()
-  return move, score
+  return score
+}
+
+func isBetter(minimize bool, score Score, than Score) bool {
+  if minimize {
+    return score < than
+  }
+  return score > than
+}
+
+func isExact(score Score, alpha Score, beta Score) bool {
+  return (alpha == MinScore || alpha < score) &&
+      (beta == MaxScore || score < beta)
 }
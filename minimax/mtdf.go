@@ -0,0 +1,51 @@
+package minimax
+
+// MTD(f) is off by default. With it on, each depth of IterativeDeepening and
+// Search, and GetMove, finds the score with a series of null window searches
+// starting from a guess: the previous depth's score, or the position's own
+// score at first. Each search only tells whether the score is above or below
+// its window, and the table saves most of the work between them. It replaces
+// aspiration windows, and needs alpha-beta pruning.
+func (state *State[M]) SetMtdf(enabled bool) {
+  state.mtdf = enabled
+}
+
+// Searches the root depth moves ahead with whichever driver is on.
+func (state *State[M]) runDriver(depth int, guess Score) (*M, Score) {
+  if state.mtdf && state.alphaBeta && !state.isRandom() {
+    return state.runMtdf(depth, guess)
+  }
+  return state.runAspiration(depth, guess)
+}
+
+// Narrows the bounds on the score with null window searches around guess
+// until they meet.
+func (state *State[M]) runMtdf(depth int, guess Score) (*M, Score) {
+  lower, upper := MinScore, MaxScore
+  score := guess
+  // Only a search that failed in the side to move's favour is sure to return
+  // a move that gets the score.
+  var move, proven *M
+  for lower < upper {
+    beta := score
+    if score == lower {
+      beta = score + 1
+    }
+    move, score = state.runRoot(depth, beta - 1, beta)
+    if state.aborted {
+      break
+    }
+    if score < beta {
+      upper = score
+    } else {
+      lower = score
+    }
+    if (score < beta) == state.minimizeStart {
+      proven = move
+    }
+  }
+  if proven != nil {
+    return proven, score
+  }
+  return move, score
+}
@@ -0,0 +1,25 @@
+package minimax
+
+import "testing"
+
+func TestMtdf_MatchesFullWindow(t *testing.T) {
+  for _, minimize := range []bool{false, true} {
+    game := makeScoredPathGame()
+    full := MakeState(game, minimize, 3)
+    mtdf := MakeState(game, minimize, 3)
+    mtdf.SetMtdf(true)
+
+    want := full.iterativeDeepening(0, 0)
+    got := mtdf.iterativeDeepening(0, 0)
+
+    if got.Move != want.Move || got.Score != want.Score {
+      t.Errorf(
+          "minimize: %v\ngot move: %v score: %v\nwant: %v %v", minimize,
+          got.Move, got.Score, want.Move, want.Score)
+    }
+    if move := mtdf.GetMove(); move != want.Move {
+      t.Errorf(
+          "minimize: %v\ngot move: %v\nwant: %v", minimize, move, want.Move)
+    }
+  }
+}
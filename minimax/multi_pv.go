@@ -0,0 +1,62 @@
+package minimax
+
+import "sort"
+
+// A root move with its exact score.
+type Ranked[M any] struct {
+  Move M
+  Score Score
+  // Starts with Move. See Result.
+  PrincipalVariation []M
+}
+
+type RankedMove = Ranked[MiniMaxMove]
+
+// Returns up to n of the best moves searched to maxDepth, best first. Moves
+// with equal scores keep the order they were searched in. Only moves that
+// could make the top n are searched exactly, so it costs less than searching
+// every move with a full window.
+func (state *State[M]) GetTopMoves(n int) []*Ranked[M] {
+  if n < 1 {
+    return nil
+  }
+  state.startSearch(0, 0)
+  minimize := state.minimizeStart
+  moves := state.game.GetAllMoves()
+  hash, key := state.positionKey()
+  entry, ok := state.table.lookup(hash, key, minimize)
+  state.orderMoves(moves, 0, entry.bestMove, ok && entry.hasMove)
+  top := make([]*Ranked[M], 0, n + 1)
+  for _, move := range moves {
+    alpha, beta := MinScore, MaxScore
+    if len(top) == n && state.alphaBeta {
+      // Only exact scores better than the worst of the top n matter.
+      if minimize {
+        beta = top[n - 1].Score
+      } else {
+        alpha = top[n - 1].Score
+      }
+    }
+    score := state.tryMove(minimize, 0, state.maxDepth, move, alpha, beta)
+    if !isExact(score, alpha, beta) {
+      continue
+    }
+    top = append(top, &Ranked[M]{move, score, nil})
+    sort.SliceStable(top, func(i int, j int) bool {
+      return isBetter(minimize, top[i].Score, top[j].Score)
+    })
+    if len(top) > n {
+      top = top[:n]
+    }
+  }
+  if len(top) > 0 {
+    state.table.store(
+        hash, key, minimize, state.maxDepth, ExactBound, top[0].Score,
+        state.moveKey(top[0].Move), true)
+  }
+  for _, ranked := range top {
+    ranked.PrincipalVariation =
+        state.principalVariation(ranked.Move, state.maxDepth)
+  }
+  return top
+}
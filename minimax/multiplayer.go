@@ -0,0 +1,128 @@
+package minimax
+
+// Games with more than two players implement this instead of Game. Players
+// are numbered from 0.
+type MultiPlayerGame[M any] interface {
+  GetAllMoves() []M
+  // One score per player, where higher is better for that player.
+  GetScores() []Score
+  // The player to move.
+  GetTurn() int
+  MakeMove(move M)
+  UndoMove()
+  String() string
+}
+
+type MiniMaxMultiPlayerGame = MultiPlayerGame[MiniMaxMove]
+
+// How a MultiPlayerState expects the other players to move.
+type MultiPlayerStrategy int
+
+const (
+  // Each player makes the move best for their own score.
+  MaxN MultiPlayerStrategy = iota
+  // The other players all make the move worst for the searching player, as if
+  // they were one opponent. Pessimistic, but prunes like alpha-beta.
+  Paranoid = iota
+)
+
+// Searches a MultiPlayerGame[M]. MiniMaxMultiPlayerState searches a
+// MiniMaxMultiPlayerGame.
+type MultiPlayerState[M any] struct {
+  game MultiPlayerGame[M]
+  maxDepth int
+  strategy MultiPlayerStrategy
+  // The player searching, set by GetMove.
+  player int
+  nodes int
+}
+
+type MiniMaxMultiPlayerState = MultiPlayerState[MiniMaxMove]
+
+func MakeMultiPlayerState(
+    game MiniMaxMultiPlayerGame, maxDepth int) *MiniMaxMultiPlayerState {
+  return MakeMultiPlayerGameState[MiniMaxMove](game, maxDepth)
+}
+
+func MakeMultiPlayerGameState[M any](
+    game MultiPlayerGame[M], maxDepth int) *MultiPlayerState[M] {
+  return &MultiPlayerState[M]{game, maxDepth, MaxN, 0, 0}
+}
+
+// The strategy is MaxN by default.
+func (state *MultiPlayerState[M]) SetStrategy(strategy MultiPlayerStrategy) {
+  state.strategy = strategy
+}
+
+// Returns the number of positions visited by the last GetMove.
+func (state *MultiPlayerState[M]) GetNodeCount() int {
+  return state.nodes
+}
+
+// Returns the best move for the player to move, or the zero value if there
+// are none.
+func (state *MultiPlayerState[M]) GetMove() M {
+  state.nodes = 0
+  state.player = state.game.GetTurn()
+  if state.strategy == Paranoid {
+    move, _ := state.runParanoid(state.maxDepth, MinScore, MaxScore)
+    return move
+  }
+  move, _ := state.runMaxN(state.maxDepth)
+  return move
+}
+
+// Returns the best move for the player to move and the scores it leads to.
+func (state *MultiPlayerState[M]) runMaxN(
+    depth int) (M, []Score) {
+  var bestMove M
+  moves := state.game.GetAllMoves()
+  if depth == 0 || len(moves) == 0 {
+    return bestMove, state.game.GetScores()
+  }
+  turn := state.game.GetTurn()
+  var bestScores []Score
+  for _, move := range moves {
+    state.game.MakeMove(move)
+    state.nodes++
+    _, scores := state.runMaxN(depth - 1)
+    state.game.UndoMove()
+    if bestScores == nil || scores[turn] > bestScores[turn] {
+      bestMove = move
+      bestScores = scores
+    }
+  }
+  return bestMove, bestScores
+}
+
+// Like State.run, with the searching player maximizing their score
+// and everyone else minimizing it.
+func (state *MultiPlayerState[M]) runParanoid(
+    depth int, alpha Score, beta Score) (M, Score) {
+  var bestMove M
+  moves := state.game.GetAllMoves()
+  if depth == 0 || len(moves) == 0 {
+    return bestMove, state.game.GetScores()[state.player]
+  }
+  maximize := state.game.GetTurn() == state.player
+  var bestScore Score
+  for i, move := range moves {
+    state.game.MakeMove(move)
+    state.nodes++
+    _, score := state.runParanoid(depth - 1, alpha, beta)
+    state.game.UndoMove()
+    if i == 0 || isBetter(!maximize, score, bestScore) {
+      bestMove = move
+      bestScore = score
+    }
+    if maximize && score > alpha {
+      alpha = score
+    } else if !maximize && score < beta {
+      beta = score
+    }
+    if alpha >= beta {
+      break
+    }
+  }
+  return bestMove, bestScore
+}
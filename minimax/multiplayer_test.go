@@ -0,0 +1,151 @@
+package minimax
+
+import (
+  "fmt"
+  "strings"
+  "testing"
+)
+
+// Players take turns in order, picking from the moves listed for the moves
+// made so far. Leaves are scored from the table.
+type treeGame struct {
+  players int
+  children map[string][]string
+  scores map[string][]Score
+  path []string
+}
+
+func (game *treeGame) key() string {
+  return strings.Join(game.path, "")
+}
+
+func (game *treeGame) GetAllMoves() []MiniMaxMove {
+  moves := []MiniMaxMove{}
+  for _, move := range game.children[game.key()] {
+    moves = append(moves, move)
+  }
+  return moves
+}
+
+func (game *treeGame) GetScores() []Score {
+  return game.scores[game.key()]
+}
+
+func (game *treeGame) GetTurn() int {
+  return len(game.path) % game.players
+}
+
+func (game *treeGame) MakeMove(move MiniMaxMove) {
+  game.path = append(game.path, move.(string))
+}
+
+func (game *treeGame) UndoMove() {
+  game.path = game.path[:len(game.path) - 1]
+}
+
+func (game *treeGame) String() string {
+  return fmt.Sprint(game.path)
+}
+
+// After a, player 1 prefers a1, which is good for player 0. After b, either
+// move is fine for player 0.
+func makeStrategyGame() *treeGame {
+  return &treeGame{
+    3,
+    map[string][]string{"": {"a", "b"}, "a": {"1", "2"}, "b": {"1", "2"}},
+    map[string][]Score{
+      "a1": {3, 5, 0}, "a2": {0, 4, 0}, "b1": {2, 1, 0}, "b2": {1, 2, 0}},
+    nil}
+}
+
+func TestMultiPlayer_MaxN(t *testing.T) {
+  state := MakeMultiPlayerState(makeStrategyGame(), 2)
+
+  if got := state.GetMove(); got != "a" {
+    t.Errorf("got move: %v\nwant: a", got)
+  }
+}
+
+func TestMultiPlayer_Paranoid(t *testing.T) {
+  state := MakeMultiPlayerState(makeStrategyGame(), 2)
+  state.SetStrategy(Paranoid)
+
+  if got := state.GetMove(); got != "b" {
+    t.Errorf("got move: %v\nwant: b", got)
+  }
+}
+
+func TestMultiPlayer_SecondPlayer(t *testing.T) {
+  game := makeStrategyGame()
+  game.MakeMove("b")
+  state := MakeMultiPlayerState(game, 1)
+
+  if got := state.GetMove(); got != "2" {
+    t.Errorf("got move: %v\nwant: 2", got)
+  }
+}
+
+// treeGame with its moves typed as strings.
+type typedTreeGame struct {
+  *treeGame
+}
+
+func (game typedTreeGame) GetAllMoves() []string {
+  return game.children[game.key()]
+}
+
+func (game typedTreeGame) MakeMove(move string) {
+  game.path = append(game.path, move)
+}
+
+func TestMultiPlayer_Typed(t *testing.T) {
+  for _, strategy := range []MultiPlayerStrategy{MaxN, Paranoid} {
+    state := MakeMultiPlayerState(makeStrategyGame(), 2)
+    state.SetStrategy(strategy)
+    typed := MakeMultiPlayerGameState[string](
+        typedTreeGame{makeStrategyGame()}, 2)
+    typed.SetStrategy(strategy)
+
+    if got, want := typed.GetMove(), state.GetMove(); got != want {
+      t.Errorf("strategy: %v\ngot move: %v\nwant: %v", strategy, got, want)
+    }
+  }
+}
+
+// Three moves per turn for depth turns, with made up scores at the leaves.
+func makeWideGame(players int, depth int) *treeGame {
+  game := &treeGame{
+      players, map[string][]string{}, map[string][]Score{}, nil}
+  var build func(key string, depth int)
+  build = func(key string, depth int) {
+    if depth == 0 {
+      scores := make([]Score, players)
+      for i := range scores {
+        scores[i] = Score(int(hashString(key + fmt.Sprint(i)) % 100))
+      }
+      game.scores[key] = scores
+      return
+    }
+    game.children[key] = []string{"a", "b", "c"}
+    for _, move := range game.children[key] {
+      build(key + move, depth - 1)
+    }
+  }
+  build("", depth)
+  return game
+}
+
+func TestMultiPlayer_ParanoidPrunes(t *testing.T) {
+  game := makeWideGame(3, 6)
+  maxN := MakeMultiPlayerState(game, 6)
+  maxN.GetMove()
+  paranoid := MakeMultiPlayerState(game, 6)
+  paranoid.SetStrategy(Paranoid)
+  paranoid.GetMove()
+
+  if paranoid.GetNodeCount() >= maxN.GetNodeCount() {
+    t.Errorf(
+        "got nodes: %v\nwant fewer than: %v", paranoid.GetNodeCount(),
+        maxN.GetNodeCount())
+  }
+}
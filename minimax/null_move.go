@@ -0,0 +1,64 @@
+package minimax
+
+// How many fewer moves ahead to search after a null move.
+const kNullMoveReduction = 2
+
+// Games can implement this to allow null-move pruning: if passing the turn
+// still leaves the side to move too good for the other side to allow, a real
+// move would be too, so the position needn't be searched fully.
+type MiniMaxNullMover interface {
+  // Passes the turn to the other side.
+  MakeNullMove()
+  UndoNullMove()
+  // Returns false where passing could be better than any move, e.g. in check
+  // or in endgames prone to zugzwang.
+  CanMakeNullMove() bool
+}
+
+// Null-move pruning is off by default. It only applies with alpha-beta pruning
+// and to games that implement MiniMaxNullMover. It makes searches much faster,
+// but can miss lines where the side to move would rather pass.
+func (state *State[M]) SetNullMovePruning(enabled bool) {
+  state.nullMove = enabled
+}
+
+// Searches the position after a null move to a reduced depth. Returns true and
+// a bound if it shows that the side to move already does too well for the
+// other side to allow.
+func (state *State[M]) tryNullMove(
+    minimize bool, ply int, depth int, alpha Score, beta Score) (bool, Score) {
+  if !state.nullMove || !state.alphaBeta || ply == 0 ||
+      depth <= kNullMoveReduction + 1 {
+    return false, 0
+  }
+  // Only a null window is needed to tell whether the bound holds.
+  if (!minimize && beta == MaxScore) || (minimize && alpha == MinScore) {
+    return false, 0
+  }
+  nullMover, ok := state.game.(MiniMaxNullMover)
+  if !ok || !nullMover.CanMakeNullMove() {
+    return false, 0
+  }
+  nullMover.MakeNullMove()
+  state.nodes++
+  // Two null moves in a row would just search the same position shallower.
+  state.afterNullMove = true
+  depth -= 1 + kNullMoveReduction
+  var score Score
+  if minimize {
+    _, score = state.run(!minimize, ply + 1, depth, alpha, alpha + 1)
+  } else {
+    _, score = state.run(!minimize, ply + 1, depth, beta - 1, beta)
+  }
+  nullMover.UndoNullMove()
+  if state.aborted {
+    return false, 0
+  }
+  if !minimize && score >= beta {
+    return true, beta
+  }
+  if minimize && score <= alpha {
+    return true, alpha
+  }
+  return false, 0
+}
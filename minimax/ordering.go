@@ -0,0 +1,154 @@
+package minimax
+
+import (
+  "fmt"
+  "sort"
+)
+
+// Games can implement this to have promising moves searched first, which lets
+// alpha-beta prune more. Moves with a positive priority, e.g. captures ranked
+// by most valuable victim then least valuable attacker, are searched before
+// the killer moves this package tracks itself. Ties are broken by the history
+// heuristic.
+type MoveOrderer[M any] interface {
+  MovePriority(move M) int
+}
+
+type MiniMaxMoveOrderer = MoveOrderer[MiniMaxMove]
+
+// Moves can implement this to be compared without printing them. Equal moves
+// must have equal keys even if they're different objects.
+type MiniMaxMoveKeyer interface {
+  MoveKey() uint64
+}
+
+// Games can implement this instead, so that moves that aren't pointers or
+// interfaces can be keyed without boxing them.
+type MoveHasher[M any] interface {
+  HashMove(move M) uint64
+}
+
+// Quiet moves that caused a cutoff at the same ply elsewhere in the tree
+// often do so again. Remember this many per ply.
+const kKillersPerPly = 2
+
+// Searched in order of tier, then value.
+type orderedMove[M any] struct {
+  move M
+  tier int
+  value int
+}
+
+const (
+  kQuietTier = iota
+  kKillerTier = iota
+  kPriorityTier = iota
+  kTableTier = iota
+)
+
+// Moves are often rebuilt by every GetAllMoves call, so they're compared and
+// stored by key.
+func (state *State[M]) moveKey(move M) uint64 {
+  if hasher, ok := state.game.(MoveHasher[M]); ok {
+    return hasher.HashMove(move)
+  }
+  if keyer, ok := any(move).(MiniMaxMoveKeyer); ok {
+    return keyer.MoveKey()
+  }
+  return hashString(fmt.Sprint(move))
+}
+
+// Returns the key of move, or false if it's nil.
+func (state *State[M]) keyOf(move *M) (uint64, bool) {
+  if move == nil {
+    return 0, false
+  }
+  return state.moveKey(*move), true
+}
+
+func (state *State[M]) movePriority(move M) int {
+  if orderer, ok := state.game.(MoveOrderer[M]); ok {
+    return orderer.MovePriority(move)
+  }
+  return 0
+}
+
+// Sorts moves so that the one keyed tableMove comes first if hasTableMove,
+// then moves the game ranks highly, then killers and then the rest by history.
+// Killers and history aren't used at the root, so that its order only depends
+// on the table and the game.
+func (state *State[M]) orderMoves(
+    moves []M, ply int, tableMove uint64, hasTableMove bool) {
+  ordered := make([]orderedMove[M], len(moves))
+  for i, move := range moves {
+    key := state.moveKey(move)
+    priority := state.movePriority(move)
+    ordered[i] = orderedMove[M]{move, kQuietTier, priority}
+    if hasTableMove && key == tableMove {
+      ordered[i].tier = kTableTier
+    } else if priority > 0 {
+      ordered[i].tier = kPriorityTier
+    } else if ply > 0 && state.isKiller(ply, key) {
+      ordered[i].tier = kKillerTier
+    } else if ply > 0 {
+      ordered[i].value = priority + state.history[key]
+    }
+  }
+  sort.SliceStable(ordered, func(i int, j int) bool {
+    if ordered[i].tier != ordered[j].tier {
+      return ordered[i].tier > ordered[j].tier
+    }
+    return ordered[i].value > ordered[j].value
+  })
+  for i := range ordered {
+    moves[i] = ordered[i].move
+  }
+}
+
+func (state *State[M]) isKiller(ply int, key uint64) bool {
+  if ply >= len(state.killers) {
+    return false
+  }
+  for _, killer := range state.killers[ply] {
+    if killer == key {
+      return true
+    }
+  }
+  return false
+}
+
+// Called when move at ply caused a cutoff with depth left to search.
+func (state *State[M]) recordCutoff(
+    ply int, depth int, move M) {
+  if state.movePriority(move) > 0 {
+    // Already searched early.
+    return
+  }
+  key := state.moveKey(move)
+  state.history[key] += depth * depth
+  for len(state.killers) <= ply {
+    state.killers = append(state.killers, make([]uint64, 0, kKillersPerPly))
+  }
+  if state.isKiller(ply, key) {
+    return
+  }
+  killers := state.killers[ply]
+  if len(killers) < kKillersPerPly {
+    killers = append(killers, 0)
+  }
+  copy(killers[1:], killers)
+  killers[0] = key
+  state.killers[ply] = killers
+}
+
+// Killers are specific to the position searched, but history is still
+// useful, just less so.
+func (state *State[M]) resetOrdering() {
+  state.killers = state.killers[:0]
+  for key := range state.history {
+    state.history[key] /= 2
+    if state.history[key] == 0 {
+      delete(state.history, key)
+    }
+  }
+}
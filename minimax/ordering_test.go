@@ -0,0 +1,63 @@
+package minimax
+
+import (
+  "reflect"
+  "testing"
+)
+
+type orderingGame struct {
+  MiniMaxGame
+  priorities map[string]int
+}
+
+func (game *orderingGame) MovePriority(move MiniMaxMove) int {
+  return game.priorities[move.(string)]
+}
+
+func makeOrderingState(priorities map[string]int) *MiniMaxState {
+  return MakeState(&orderingGame{nil, priorities}, false, 1)
+}
+
+func TestOrderMoves(t *testing.T) {
+  state := makeOrderingState(map[string]int{"capture": 5, "bigCapture": 9})
+  state.recordCutoff(1, 2, "killer")
+  state.recordCutoff(2, 3, "history")
+  moves := []MiniMaxMove{
+      "quiet", "history", "killer", "capture", "table", "bigCapture"}
+
+  state.orderMoves(moves, 1, state.moveKey("table"), true)
+
+  want := []MiniMaxMove{
+      "table", "bigCapture", "capture", "killer", "history", "quiet"}
+  if !reflect.DeepEqual(moves, want) {
+    t.Errorf("got order: %v\nwant: %v", moves, want)
+  }
+}
+
+func TestOrderMoves_RootIgnoresHeuristics(t *testing.T) {
+  state := makeOrderingState(map[string]int{})
+  state.recordCutoff(0, 2, "killer")
+  moves := []MiniMaxMove{"a", "killer", "b"}
+
+  state.orderMoves(moves, 0, 0, false)
+
+  want := []MiniMaxMove{"a", "killer", "b"}
+  if !reflect.DeepEqual(moves, want) {
+    t.Errorf("got order: %v\nwant: %v", moves, want)
+  }
+}
+
+func TestRecordCutoff_KeepsNewestKillers(t *testing.T) {
+  state := makeOrderingState(map[string]int{"capture": 1})
+  state.recordCutoff(1, 1, "a")
+  state.recordCutoff(1, 1, "b")
+  state.recordCutoff(1, 1, "c")
+  state.recordCutoff(1, 1, "capture")
+
+  isKiller := func(move MiniMaxMove) bool {
+    return state.isKiller(1, state.moveKey(move))
+  }
+  if isKiller("a") || !isKiller("b") || !isKiller("c") || isKiller("capture") {
+    t.Errorf("got killers: %v\nwant b and c", state.killers[1])
+  }
+}
@@ -0,0 +1,153 @@
+package minimax
+
+import "sync"
+
+// Games can implement this to be searched on several goroutines at once.
+// Moves from GetAllMoves must also work on the copies.
+type Cloner[M any] interface {
+  Clone() Game[M]
+}
+
+type MiniMaxCloner = Cloner[MiniMaxMove]
+
+// Splits the root moves between this many goroutines, each searching its own
+// copy of the game. Only used if the game implements MiniMaxCloner. Picks the
+// same move as searching on one goroutine.
+func (state *State[M]) SetRootWorkers(workers int) {
+  state.rootWorkers = workers
+}
+
+// The best root move found so far by any worker.
+type rootBest struct {
+  mutex sync.Mutex
+  found bool
+  index int
+  score Score
+}
+
+// Returns the window to search the root move at index with. Moves after the
+// best so far must beat it to be picked, but moves before it only need to tie
+// it, so they get a window one wider.
+func (best *rootBest) window(
+    minimize bool, index int, alphaBeta bool) (Score, Score) {
+  best.mutex.Lock()
+  defer best.mutex.Unlock()
+  if !best.found || !alphaBeta {
+    return MinScore, MaxScore
+  }
+  if minimize {
+    if index < best.index && best.score != MaxScore {
+      return MinScore, best.score + 1
+    }
+    return MinScore, best.score
+  }
+  if index < best.index && best.score != MinScore {
+    return best.score - 1, MaxScore
+  }
+  return best.score, MaxScore
+}
+
+// Records the score of the root move at index if it's exact and better than
+// the best so far, breaking ties by index like the sequential search does.
+func (best *rootBest) update(
+    minimize bool, index int, score Score, alpha Score, beta Score) {
+  if !isExact(score, alpha, beta) {
+    return
+  }
+  best.mutex.Lock()
+  defer best.mutex.Unlock()
+  if !best.found || isBetter(minimize, score, best.score) ||
+      (score == best.score && index < best.index) {
+    best.found = true
+    best.index = index
+    best.score = score
+  }
+}
+
+
+func (state *State[M]) runParallel(
+    cloner Cloner[M], depth int) (*M, Score) {
+  minimize := state.minimizeStart
+  hash, key := state.positionKey()
+  moves := state.game.GetAllMoves()
+  if len(moves) == 0 {
+    return nil, state.game.GetScore()
+  }
+  entry, ok := state.table.lookup(hash, key, minimize)
+  state.orderMoves(moves, 0, entry.bestMove, ok && entry.hasMove)
+  indices := make(chan int, len(moves))
+  for i := range moves {
+    indices <- i
+  }
+  close(indices)
+
+  best := &rootBest{}
+  workers := state.makeWorkers(cloner, state.rootWorkers, state.stop)
+  var wait sync.WaitGroup
+  for _, worker := range workers {
+    wait.Add(1)
+    go func(worker *State[M]) {
+      defer wait.Done()
+      for i := range indices {
+        if worker.checkLimits() {
+          return
+        }
+        alpha, beta := best.window(minimize, i, state.alphaBeta)
+        score := worker.tryMove(minimize, 0, depth, moves[i], alpha, beta)
+        if worker.aborted {
+          return
+        }
+        best.update(minimize, i, score, alpha, beta)
+      }
+    }(worker)
+  }
+  wait.Wait()
+
+  for _, worker := range workers {
+    state.nodes += worker.nodes
+    state.tableHits += worker.tableHits
+    state.aborted = state.aborted || worker.aborted
+  }
+  if !best.found {
+    return nil, 0
+  }
+  if !state.aborted {
+    state.table.store(
+        hash, key, minimize, depth, ExactBound, best.score,
+        state.moveKey(moves[best.index]), true)
+  }
+  return &moves[best.index], best.score
+}
+
+// Makes count copies of state, each searching a fresh copy of the game with a
+// share of what's left of the limits. They all share state's table. stop may
+// be nil.
+func (state *State[M]) makeWorkers(
+    cloner Cloner[M], count int, stop *int32) []*State[M] {
+  nodeLimit := 0
+  if state.nodeLimit > 0 {
+    nodeLimit = (state.nodeLimit - state.nodes) / count
+    if nodeLimit < 1 {
+      nodeLimit = 1
+    }
+  }
+  workers := make([]*State[M], count)
+  for i := range workers {
+    worker := *state
+    worker.game = cloner.Clone()
+    worker.nodes = 0
+    worker.tableHits = 0
+    worker.nodeLimit = nodeLimit
+    worker.aborted = false
+    worker.rootWorkers = 1
+    worker.stop = stop
+    // Heuristics aren't safe to share.
+    worker.killers = nil
+    worker.history = make(map[uint64]int)
+    worker.traceDepth = 0
+    worker.tracePath = nil
+    worker.ponder = nil
+    workers[i] = &worker
+  }
+  return workers
+}
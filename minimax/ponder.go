@@ -0,0 +1,97 @@
+package minimax
+
+import (
+  "context"
+  "sync/atomic"
+)
+
+// A search of the position after the expected reply, running on another
+// goroutine while the opponent thinks.
+type ponder[M any] struct {
+  worker *State[M]
+  reply M
+  // The position being searched.
+  hash uint64
+  key string
+  stop int32
+  done chan struct{}
+  result *Result[M]
+}
+
+// Starts searching the position after move and the reply the table expects
+// to it, on another goroutine and a copy of the game, so that the search goes
+// on while the opponent thinks. If the opponent plays that reply, the next
+// Search carries on from where pondering got to and its result counts the
+// time spent pondering. Any other search stops pondering first, but can still
+// use what it left in the table. Returns false if the game isn't a Cloner or
+// no reply is expected.
+func (state *State[M]) StartPondering(move M) bool {
+  state.StopPondering()
+  cloner, ok := state.game.(Cloner[M])
+  if !ok {
+    return false
+  }
+  worker := state.makeWorkers(cloner, 1, nil)[0]
+  worker.progress = nil
+  worker.game.MakeMove(move)
+  reply, ok := worker.tableMove(!state.minimizeStart)
+  if !ok {
+    return false
+  }
+  worker.game.MakeMove(reply)
+  pondering := &ponder[M]{worker: worker, reply: reply}
+  pondering.hash, pondering.key = worker.positionKey()
+  pondering.done = make(chan struct{})
+  worker.stop = &pondering.stop
+  state.ponder = pondering
+  go func() {
+    defer close(pondering.done)
+    pondering.result = worker.iterativeDeepening(0, 0)
+  }()
+  return true
+}
+
+// Returns the reply being pondered, or false if pondering is off.
+func (state *State[M]) GetPonderMove() (M, bool) {
+  if state.ponder == nil {
+    var none M
+    return none, false
+  }
+  return state.ponder.reply, true
+}
+
+// Stops pondering, if it's on, and waits for it to finish.
+func (state *State[M]) StopPondering() {
+  if state.ponder == nil {
+    return
+  }
+  atomic.StoreInt32(&state.ponder.stop, 1)
+  <-state.ponder.done
+  state.ponder = nil
+}
+
+// If pondering the current position, lets it go on until it reaches maxDepth
+// or ctx is done, and returns its result. Otherwise stops pondering and
+// returns nil.
+func (state *State[M]) finishPondering(ctx context.Context) *Result[M] {
+  pondering := state.ponder
+  if pondering == nil {
+    return nil
+  }
+  hash, key := state.positionKey()
+  if hash != pondering.hash || key != pondering.key {
+    state.StopPondering()
+    return nil
+  }
+  state.ponder = nil
+  select {
+    case <-ctx.Done():
+      atomic.StoreInt32(&pondering.stop, 1)
+      <-pondering.done
+    case <-pondering.done:
+  }
+  state.nodes = pondering.worker.nodes
+  state.tableHits = pondering.worker.tableHits
+  state.aborted = pondering.worker.aborted
+  return pondering.result
+}
@@ -0,0 +1,70 @@
+package minimax
+
+import (
+  "context"
+  "testing"
+)
+
+// Max's best move is b, after which min is expected to reply b.
+func makePonderingState() (*pathGame, *MiniMaxState) {
+  game := &pathGame{
+      nil, 3, map[string]Score{
+          "a a a": 1, "a a b": 1, "a b a": 1, "a b b": 1,
+          "b a a": 8, "b a b": 6, "b b a": 4, "b b b": 2}}
+  return game, MakeState(game, false, 3)
+}
+
+func TestPondering_Hit(t *testing.T) {
+  game, state := makePonderingState()
+  move := state.GetMove()
+  if !state.StartPondering(move) {
+    t.Fatal("didn't start pondering")
+  }
+  reply, _ := state.GetPonderMove()
+  pondering := state.ponder
+  game.MakeMove(move)
+  game.MakeMove(reply)
+
+  result := state.Search(context.Background())
+
+  _, fresh := makePonderingState()
+  fresh.game.MakeMove(move)
+  fresh.game.MakeMove(reply)
+  want := fresh.Search(context.Background())
+  if move != "b" || reply != "b" || result != pondering.result ||
+      result.Move != want.Move || result.Score != want.Score ||
+      !result.Complete {
+    t.Errorf(
+        "got moves: %v %v result: %v\nwant: b b %v", move, reply, result,
+        want)
+  }
+  if _, ok := state.GetPonderMove(); ok {
+    t.Errorf("still pondering")
+  }
+}
+
+func TestPondering_Miss(t *testing.T) {
+  game, state := makePonderingState()
+  move := state.GetMove()
+  state.StartPondering(move)
+  pondering := state.ponder
+  game.MakeMove(move)
+  game.MakeMove("a")
+
+  result := state.Search(context.Background())
+
+  if result == pondering.result || result.Move != "a" || result.Score != 8 {
+    t.Errorf("got result: %v\nwant a fresh search picking a for 8", result)
+  }
+}
+
+func TestPondering_StoppedByOtherSearches(t *testing.T) {
+  _, state := makePonderingState()
+  state.StartPondering(state.GetMove())
+
+  state.GetMove()
+
+  if _, ok := state.GetPonderMove(); ok {
+    t.Errorf("still pondering")
+  }
+}
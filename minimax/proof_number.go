@@ -0,0 +1,167 @@
+package minimax
+
+// Larger than any real proof or disproof number.
+const kProofInfinity = int(^uint(0) >> 2)
+
+// What a proof-number search found out about a position.
+type ProofStatus int
+
+const (
+  // The node limit ran out first.
+  Unproven ProofStatus = iota
+  // The side to move can force a win.
+  Proven = iota
+  // The side to move can't force a win, but may still draw.
+  Disproven = iota
+)
+
+type Proof[M any] struct {
+  Status ProofStatus
+  // A first move that forces the win, if it's Proven.
+  Move M
+  // Moves made during the search.
+  Nodes int
+}
+
+type proofNode[M any] struct {
+  move M
+  // How many more positions must be proven or disproven, at least, to
+  // prove or disprove this one.
+  proof int
+  disproof int
+  // nil until the node is expanded, and again once it's solved.
+  children []*proofNode[M]
+  // The moves found when the node was first reached.
+  moves []M
+}
+
+type proofSearch[M any] struct {
+  game Game[M]
+  // The score the attacker wins with.
+  win Score
+  nodes int
+}
+
+// Searches for a forced win for the side to move with proof-number search,
+// which grows the tree towards the positions that are quickest to prove or
+// disprove instead of searching to a fixed depth. Only MaxScore and MinScore
+// count as wins, and positions without moves that aren't wins count as draws.
+// The whole tree is kept in memory, so nodeLimit bounds memory too. It's
+// checked before each position is expanded, so a search can go over it by
+// one position's moves. A nodeLimit of zero means no limit, which only suits
+// small games.
+func ProveWin[M any](game Game[M], minimize bool, nodeLimit int) *Proof[M] {
+  search := &proofSearch[M]{game, MaxScore, 0}
+  if minimize {
+    search.win = MinScore
+  }
+  var none M
+  root := search.evaluate(none, true)
+  for root.proof != 0 && root.disproof != 0 &&
+      (nodeLimit <= 0 || search.nodes < nodeLimit) {
+    search.visit(root, true, true)
+  }
+  proof := &Proof[M]{Unproven, none, search.nodes}
+  if root.proof == 0 {
+    proof.Status = Proven
+    for _, child := range root.children {
+      if child.proof == 0 {
+        proof.Move = child.move
+        break
+      }
+    }
+  } else if root.disproof == 0 {
+    proof.Status = Disproven
+  }
+  return proof
+}
+
+// Makes a node for the current position, reached by move. attacking is true
+// if the side that's trying to win is to move.
+func (search *proofSearch[M]) evaluate(
+    move M, attacking bool) *proofNode[M] {
+  node := &proofNode[M]{move: move}
+  score := search.game.GetScore()
+  switch {
+    case score == search.win:
+      node.proof, node.disproof = 0, kProofInfinity
+    case score == MaxScore || score == MinScore:
+      node.proof, node.disproof = kProofInfinity, 0
+    default:
+      node.moves = search.game.GetAllMoves()
+      if len(node.moves) == 0 {
+        node.proof, node.disproof = kProofInfinity, 0
+      } else if attacking {
+        // Any move might prove it, but every move has to be disproven.
+        node.proof, node.disproof = 1, len(node.moves)
+      } else {
+        node.proof, node.disproof = len(node.moves), 1
+      }
+  }
+  return node
+}
+
+// Expands the most-proving position under node, then updates the numbers on
+// the way back up.
+func (search *proofSearch[M]) visit(
+    node *proofNode[M], attacking bool, root bool) {
+  if node.children == nil {
+    node.children = make([]*proofNode[M], len(node.moves))
+    for i, move := range node.moves {
+      search.game.MakeMove(move)
+      search.nodes++
+      node.children[i] = search.evaluate(move, !attacking)
+      search.game.UndoMove()
+    }
+    node.moves = nil
+  } else {
+    child := node.mostProving(attacking)
+    search.game.MakeMove(child.move)
+    search.visit(child, !attacking, false)
+    search.game.UndoMove()
+  }
+  node.update(attacking)
+  if !root && (node.proof == 0 || node.disproof == 0) {
+    // Never visited again.
+    node.children = nil
+  }
+}
+
+// The attacker needs only one move to work, and the defender only one move
+// to refute it.
+func (node *proofNode[M]) mostProving(attacking bool) *proofNode[M] {
+  var best *proofNode[M]
+  for _, child := range node.children {
+    if best == nil || (attacking && child.proof < best.proof) ||
+        (!attacking && child.disproof < best.disproof) {
+      best = child
+    }
+  }
+  return best
+}
+
+func (node *proofNode[M]) update(attacking bool) {
+  least, sum := kProofInfinity, 0
+  for _, child := range node.children {
+    value, other := child.proof, child.disproof
+    if !attacking {
+      value, other = other, value
+    }
+    if value < least {
+      least = value
+    }
+    sum = addProof(sum, other)
+  }
+  if attacking {
+    node.proof, node.disproof = least, sum
+  } else {
+    node.proof, node.disproof = sum, least
+  }
+}
+
+func addProof(a int, b int) int {
+  if a >= kProofInfinity - b {
+    return kProofInfinity
+  }
+  return a + b
+}
@@ -0,0 +1,34 @@
+package minimax
+
+import "testing"
+
+// Max wins after a whatever min does, but not always after b.
+func makeProofGame() *pathGame {
+  return &pathGame{
+      nil, 2, map[string]Score{
+          "a a": MaxScore, "a b": MaxScore, "b a": MaxScore}}
+}
+
+func TestProveWin(t *testing.T) {
+  proof := ProveWin[MiniMaxMove](makeProofGame(), false, 0)
+
+  if proof.Status != Proven || proof.Move != "a" {
+    t.Errorf("got proof: %v\nwant proven with a", proof)
+  }
+}
+
+func TestProveWin_Disproven(t *testing.T) {
+  proof := ProveWin[MiniMaxMove](makeProofGame(), true, 0)
+
+  if proof.Status != Disproven {
+    t.Errorf("got proof: %v\nwant disproven", proof)
+  }
+}
+
+func TestProveWin_NodeLimit(t *testing.T) {
+  proof := ProveWin[MiniMaxMove](makeProofGame(), false, 1)
+
+  if proof.Status != Unproven || proof.Nodes != 2 {
+    t.Errorf("got proof: %v\nwant unproven after 2 nodes", proof)
+  }
+}
@@ -0,0 +1,63 @@
+package minimax
+
+// Searched in full before late move reductions start.
+const kLateMoveStart = 3
+
+// How many fewer moves ahead late moves are searched at first.
+const kLateMoveReduction = 1
+
+// The default for how much a quiet move can improve the score.
+const kDefaultFutilityMargin = Score(1)
+
+// Late move reductions are off by default. With them on, quiet moves ordered
+// after the first few are searched less deep with a null window, and only
+// searched in full if they turn out better than the moves before them.
+func (state *State[M]) SetLateMoveReductions(enabled bool) {
+  state.lateMoveReductions = enabled
+}
+
+// Futility pruning is off by default. With it on, quiet moves one move from
+// the leaves aren't searched if the current score plus the margin can't reach
+// the window.
+func (state *State[M]) SetFutilityPruning(enabled bool) {
+  state.futilityPruning = enabled
+}
+
+// How much a quiet move can improve the score, in the game's units. Smaller
+// margins prune more but can miss more.
+func (state *State[M]) SetFutilityMargin(margin Score) {
+  state.futilityMargin = margin
+}
+
+// Quiet moves are those the game doesn't rank and that haven't caused a
+// cutoff at this ply.
+func (state *State[M]) isQuiet(ply int, move M) bool {
+  return state.movePriority(move) <= 0 &&
+      !state.isKiller(ply, state.moveKey(move))
+}
+
+// Returns true and the bound for quiet moves if they can't reach the window
+// of a search depth moves ahead.
+func (state *State[M]) futileScore(
+    minimize bool, ply int, depth int) (bool, Score) {
+  if !state.futilityPruning || !state.alphaBeta || ply == 0 || depth != 1 {
+    return false, 0
+  }
+  score := state.game.GetScore()
+  if score == MaxScore || score == MinScore {
+    return false, 0
+  }
+  if minimize {
+    return true, addScores(score, -state.futilityMargin)
+  }
+  return true, addScores(score, state.futilityMargin)
+}
+
+// Returns true if move should first be searched kLateMoveReduction moves
+// less deep.
+func (state *State[M]) reducesLateMove(
+    ply int, depth int, index int, move M) bool {
+  return state.lateMoveReductions && state.alphaBeta && ply > 0 &&
+      depth > kLateMoveReduction + 1 && index >= kLateMoveStart &&
+      state.isQuiet(ply, move)
+}
@@ -0,0 +1,72 @@
+package minimax
+
+// Searches past maxDepth this many moves at most.
+const kMaxQuiescenceDepth = 16
+
+// Games can implement this so that the search keeps going past maxDepth
+// until the position is quiet. Should return the legal moves that change the
+// score a lot, e.g. captures and promotions in chess.
+type NoisyMover[M any] interface {
+  GetNoisyMoves() []M
+}
+
+type MiniMaxNoisyMover = NoisyMover[MiniMaxMove]
+
+// Quiescence search is on by default for games that implement
+// MiniMaxNoisyMover.
+func (state *State[M]) SetQuiescence(enabled bool) {
+  state.quiescence = enabled
+}
+
+// Scores the current position, which scores standPat as is, by playing noisy
+// moves until it's quiet. The side to move may stand pat rather than make a
+// noisy move, so standPat is a bound on the score.
+func (state *State[M]) quiesce(
+    noisy NoisyMover[M], minimize bool, ply int, depth int, alpha Score,
+    beta Score, standPat Score) Score {
+  if state.alphaBeta {
+    if minimize && standPat < beta {
+      beta = standPat
+    } else if !minimize && standPat > alpha {
+      alpha = standPat
+    }
+    if alpha >= beta {
+      return standPat
+    }
+  }
+  moves := noisy.GetNoisyMoves()
+  // Only the game's priorities, since killers and history are for quiet
+  // moves.
+  state.orderMoves(moves, 0, 0, false)
+  bestScore := standPat
+  for _, move := range moves {
+    if state.checkLimits() {
+      break
+    }
+    state.game.MakeMove(move)
+    state.nodes++
+    score := state.game.GetScore()
+    if score == MaxScore || score == MinScore {
+      score = mateScore(score, ply + 1)
+    } else if depth < kMaxQuiescenceDepth {
+      score = state.quiesce(
+          noisy, !minimize, ply + 1, depth + 1, alpha, beta, score)
+    }
+    state.game.UndoMove()
+    if isBetter(minimize, score, bestScore) {
+      bestScore = score
+    }
+    if !state.alphaBeta {
+      continue
+    }
+    if minimize && score < beta {
+      beta = score
+    } else if !minimize && score > alpha {
+      alpha = score
+    }
+    if alpha >= beta {
+      break
+    }
+  }
+  return bestScore
+}
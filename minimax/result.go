@@ -0,0 +1,111 @@
+package minimax
+
+import (
+  "fmt"
+  "strings"
+  "time"
+)
+
+type Result[M any] struct {
+  // The zero value if the game is over.
+  Move M
+  // False if the game is over.
+  HasMove bool
+  Score Score
+  // The moves both sides are expected to play, starting with Move. Read from
+  // the transposition table, so it may be cut short.
+  PrincipalVariation []M
+  // The deepest search that finished.
+  Depth int
+  Nodes int
+  // Positions whose score came from the transposition table.
+  TableHits int
+  Elapsed time.Duration
+  // False if the search was stopped before reaching maxDepth.
+  Complete bool
+}
+
+type SearchResult = Result[MiniMaxMove]
+
+func (result *Result[M]) setMove(move *M) {
+  var zero M
+  result.Move, result.HasMove = zero, move != nil
+  if move != nil {
+    result.Move = *move
+  }
+}
+
+func (result *Result[M]) String() string {
+  builder := &strings.Builder{}
+  builder.WriteString(fmt.Sprintf(
+      "depth %v score %v nodes %v hits %v time %v pv", result.Depth,
+      ScoreString(result.Score), result.Nodes, result.TableHits,
+      result.Elapsed))
+  for _, move := range result.PrincipalVariation {
+    builder.WriteString(fmt.Sprint(" ", move))
+  }
+  return builder.String()
+}
+
+// Called with the result of every finished depth while searching with
+// IterativeDeepening, GetMoveContext or Search.
+func (state *State[M]) SetProgressCallback(
+    callback func(result *Result[M])) {
+  state.progress = callback
+}
+
+func (state *State[M]) fillStats(result *Result[M], start time.Time) {
+  result.Nodes = state.nodes
+  result.TableHits = state.tableHits
+  result.Elapsed = time.Since(start)
+}
+
+// Follows the best moves stored in the table after playing move from the
+// current position.
+func (state *State[M]) principalVariation(move M, depth int) []M {
+  variation := make([]M, 1, depth)
+  variation[0] = move
+  state.game.MakeMove(move)
+  minimize := !state.minimizeStart
+  for len(variation) < depth {
+    move, ok := state.tableMove(minimize)
+    if !ok {
+      break
+    }
+    state.game.MakeMove(move)
+    variation = append(variation, move)
+    minimize = !minimize
+  }
+  for range variation {
+    state.game.UndoMove()
+  }
+  return variation
+}
+
+// Returns the best move stored in the table for the current position, if
+// there is one.
+func (state *State[M]) tableMove(minimize bool) (M, bool) {
+  var none M
+  hash, key := state.positionKey()
+  entry, ok := state.table.lookup(hash, key, minimize)
+  if !ok || !entry.hasMove {
+    return none, false
+  }
+  moves := state.game.GetAllMoves()
+  index := state.findMove(moves, entry.bestMove)
+  if index < 0 {
+    // Hash collision
+    return none, false
+  }
+  return moves[index], true
+}
+
+// Returns the index of the move with the given key, or -1.
+func (state *State[M]) findMove(moves []M, key uint64) int {
+  for i, move := range moves {
+    if state.moveKey(move) == key {
+      return i
+    }
+  }
+  return -1
+}
@@ -0,0 +1,131 @@
+package minimax
+
+import (
+  "math"
+  "math/rand"
+)
+
+// Moves more than this many temperatures worse than the best are given no
+// chance at all, so they needn't be scored exactly.
+const kTemperatureRange = 10
+
+// Breaks ties between equally good root moves at random instead of picking
+// the first in search order. The same seed picks the same moves, so games
+// between fixed-depth players can be replayed. Root moves are searched one
+// at a time while this is on, even with root workers or Lazy SMP threads.
+func (state *State[M]) SetRandomSeed(seed int64) {
+  state.random = rand.New(rand.NewSource(seed))
+}
+
+// With a positive temperature, root moves scoring within a few temperatures
+// of the best may be picked too, with chances proportional to
+// exp(-(how much worse than the best) / temperature). Zero, the default,
+// only picks best moves. Uses the random seed, or 0 if there isn't one.
+func (state *State[M]) SetTemperature(temperature float64) {
+  state.temperature = temperature
+}
+
+func (state *State[M]) isRandom() bool {
+  return state.random != nil || state.temperature > 0
+}
+
+// How much worse than the best a root move can score and still be picked.
+func (state *State[M]) sampleMargin() Score {
+  if state.temperature <= 0 {
+    return 0
+  }
+  return Score(math.Ceil(state.temperature * kTemperatureRange))
+}
+
+// Scores every root move that might be picked exactly, then picks one.
+func (state *State[M]) runSampled(depth int) (*M, Score) {
+  minimize := state.minimizeStart
+  chance, ok := state.game.(ChanceGame[M])
+  if ok && chance.IsChanceNode() {
+    return state.run(minimize, 0, depth, MinScore, MaxScore)
+  }
+  moves := state.game.GetAllMoves()
+  if len(moves) == 0 {
+    return nil, mateScore(state.game.GetScore(), 0)
+  }
+  hash, key := state.positionKey()
+  entry, ok := state.table.lookup(hash, key, minimize)
+  state.orderMoves(moves, 0, entry.bestMove, ok && entry.hasMove)
+  margin := state.sampleMargin()
+  scores := make([]Score, len(moves))
+  candidates := make([]int, 0, len(moves))
+  best := -1
+  for i, move := range moves {
+    alpha, beta := MinScore, MaxScore
+    if best >= 0 && state.alphaBeta {
+      // Only scores within the margin of the best so far matter.
+      if minimize {
+        beta = addScores(scores[best], margin + 1)
+      } else {
+        alpha = addScores(scores[best], -margin - 1)
+      }
+    }
+    score := state.tryMove(minimize, 0, depth, move, alpha, beta)
+    if state.aborted {
+      break
+    }
+    if !isExact(score, alpha, beta) {
+      continue
+    }
+    scores[i] = score
+    candidates = append(candidates, i)
+    if best < 0 || isBetter(minimize, score, scores[best]) {
+      best = i
+    }
+  }
+  if best < 0 {
+    return nil, 0
+  }
+  if state.aborted {
+    return &moves[best], scores[best]
+  }
+  state.table.store(
+      hash, key, minimize, depth, ExactBound, scores[best],
+      state.moveKey(moves[best]), true)
+  picked := state.pickMove(minimize, scores, candidates, scores[best])
+  return &moves[picked], scores[picked]
+}
+
+// Picks one of the candidates within the margin of best at random, weighted
+// by temperature.
+func (state *State[M]) pickMove(
+    minimize bool, scores []Score, candidates []int, best Score) int {
+  if state.random == nil {
+    state.random = rand.New(rand.NewSource(0))
+  }
+  margin := float64(state.sampleMargin())
+  weights := make([]float64, len(candidates))
+  total := 0.0
+  for i, index := range candidates {
+    worse := float64(best) - float64(scores[index])
+    if minimize {
+      worse = -worse
+    }
+    if worse > margin {
+      continue
+    }
+    weights[i] = 1
+    if state.temperature > 0 {
+      weights[i] = math.Exp(-worse / state.temperature)
+    }
+    total += weights[i]
+  }
+  sample := state.random.Float64() * total
+  picked := -1
+  for i, index := range candidates {
+    if weights[i] == 0 {
+      continue
+    }
+    picked = index
+    sample -= weights[i]
+    if sample < 0 {
+      break
+    }
+  }
+  return picked
+}
@@ -0,0 +1,43 @@
+package minimax
+
+import "testing"
+
+func countPicks(
+    state *MiniMaxState, minimize bool, scores []Score) map[int]int {
+  candidates := make([]int, len(scores))
+  best := scores[0]
+  for i, score := range scores {
+    candidates[i] = i
+    if isBetter(minimize, score, best) {
+      best = score
+    }
+  }
+  counts := make(map[int]int)
+  for i := 0; i < 10000; i++ {
+    counts[state.pickMove(minimize, scores, candidates, best)]++
+  }
+  return counts
+}
+
+func TestPickMove_BreaksTies(t *testing.T) {
+  state := makeOrderingState(nil)
+  state.SetRandomSeed(1)
+
+  counts := countPicks(state, false, []Score{4, 5, 5})
+
+  if counts[0] != 0 || counts[1] < 4500 || counts[2] < 4500 {
+    t.Errorf("got picks: %v\nwant the two 5s evenly", counts)
+  }
+}
+
+func TestPickMove_Temperature(t *testing.T) {
+  state := makeOrderingState(nil)
+  state.SetTemperature(1)
+
+  counts := countPicks(state, true, []Score{-10, -9, 5})
+
+  ratio := float64(counts[0]) / float64(counts[1])
+  if counts[2] != 0 || ratio < 2.4 || ratio > 3.1 {
+    t.Errorf("got picks: %v\nwant -10 about e times as often as -9", counts)
+  }
+}
@@ -0,0 +1,161 @@
+package minimax
+
+import (
+  "encoding/gob"
+  "io"
+  "sort"
+)
+
+// The value of a position with perfect play from both sides.
+type Solution struct {
+  // The score the game ends with: MaxScore or MinScore for a win, and
+  // usually 0 for a draw.
+  Score Score
+  // How many moves until the game ends. Winners win as fast as they can and
+  // losers lose as slowly as they can.
+  Distance int
+}
+
+type solutionKey struct {
+  key string
+  minimize bool
+}
+
+// Solutions for every position reachable from the one a game was solved
+// from, keyed by StringKey and side to move.
+type SolutionTable struct {
+  solutions map[solutionKey]Solution
+}
+
+// Saved by SolutionTable.Save.
+type solvedPosition struct {
+  Key string
+  Minimize bool
+  Score Score
+  Distance int
+}
+
+// Returns the solution for the position with the given StringKey, or false if
+// it wasn't reached.
+func (table *SolutionTable) Get(key string, minimize bool) (Solution, bool) {
+  solution, ok := table.solutions[solutionKey{key, minimize}]
+  return solution, ok
+}
+
+func (table *SolutionTable) Size() int {
+  return len(table.solutions)
+}
+
+// Writes the table with encoding/gob, sorted so that the same table is always
+// written the same way.
+func (table *SolutionTable) Save(writer io.Writer) error {
+  positions := make([]solvedPosition, 0, len(table.solutions))
+  for key, solution := range table.solutions {
+    positions = append(positions, solvedPosition{
+        key.key, key.minimize, solution.Score, solution.Distance})
+  }
+  sort.Slice(positions, func(i int, j int) bool {
+    if positions[i].Key != positions[j].Key {
+      return positions[i].Key < positions[j].Key
+    }
+    return !positions[i].Minimize && positions[j].Minimize
+  })
+  return gob.NewEncoder(writer).Encode(positions)
+}
+
+// Reads a table written by Save.
+func LoadSolutionTable(reader io.Reader) (*SolutionTable, error) {
+  var positions []solvedPosition
+  if err := gob.NewDecoder(reader).Decode(&positions); err != nil {
+    return nil, err
+  }
+  table := &SolutionTable{make(map[solutionKey]Solution, len(positions))}
+  for _, position := range positions {
+    table.solutions[solutionKey{position.Key, position.Minimize}] =
+        Solution{position.Score, position.Distance}
+  }
+  return table, nil
+}
+
+// Solves every position reachable from the current one by visiting each
+// once. The game ends when there are no moves or GetScore returns MaxScore or
+// MinScore, and the scores of other positions are ignored. Positions that
+// repeat one earlier in the same line are scored as draws, so only games
+// that can't repeat are solved exactly. Only practical for small games.
+func Solve[M any](game Game[M], minimize bool) *SolutionTable {
+  table := &SolutionTable{make(map[solutionKey]Solution)}
+  solve(game, minimize, table, make(map[solutionKey]bool))
+  return table
+}
+
+func solve[M any](
+    game Game[M], minimize bool, table *SolutionTable,
+    line map[solutionKey]bool) Solution {
+  key := solutionKey{game.StringKey(), minimize}
+  if solution, ok := table.solutions[key]; ok {
+    return solution
+  }
+  if line[key] {
+    return Solution{0, 0}
+  }
+  score := game.GetScore()
+  var moves []M
+  if score != MaxScore && score != MinScore {
+    moves = game.GetAllMoves()
+  }
+  if len(moves) == 0 {
+    table.solutions[key] = Solution{score, 0}
+    return Solution{score, 0}
+  }
+  line[key] = true
+  var best Solution
+  for i, move := range moves {
+    game.MakeMove(move)
+    solution := solve(game, !minimize, table, line)
+    game.UndoMove()
+    solution.Distance++
+    if i == 0 || solution.isBetter(minimize, best) {
+      best = solution
+    }
+  }
+  delete(line, key)
+  table.solutions[key] = best
+  return best
+}
+
+// Returns true if the side to move should prefer solution to than.
+func (solution Solution) isBetter(minimize bool, than Solution) bool {
+  if solution.Score != than.Score {
+    return isBetter(minimize, solution.Score, than.Score)
+  }
+  lost := solution.Score == MinScore
+  if minimize {
+    lost = solution.Score == MaxScore
+  }
+  if lost {
+    return solution.Distance > than.Distance
+  }
+  return solution.Distance < than.Distance
+}
+
+// Returns the move that keeps the best solution, or false if there are no
+// moves or the table doesn't have them.
+func SolvedMove[M any](
+    table *SolutionTable, game Game[M], minimize bool) (M, bool) {
+  var bestMove M
+  var best Solution
+  found := false
+  for _, move := range game.GetAllMoves() {
+    game.MakeMove(move)
+    solution, ok := table.Get(game.StringKey(), !minimize)
+    game.UndoMove()
+    if !ok {
+      continue
+    }
+    solution.Distance++
+    if !found || solution.isBetter(minimize, best) {
+      bestMove, best, found = move, solution, true
+    }
+  }
+  return bestMove, found
+}
@@ -0,0 +1,56 @@
+package minimax
+
+import (
+  "bytes"
+  "reflect"
+  "testing"
+)
+
+// Max wins after b at once, or after a a move later.
+func makeSolverGame() *pathGame {
+  return &pathGame{
+      nil, 2, map[string]Score{
+          "a a": MaxScore, "a b": MaxScore, "b": MaxScore}}
+}
+
+func TestSolve(t *testing.T) {
+  table := Solve[MiniMaxMove](makeSolverGame(), false)
+
+  root, _ := table.Get("", false)
+  if root != (Solution{MaxScore, 1}) || table.Size() != 5 {
+    t.Errorf(
+        "got root: %v size: %v\nwant: {%v 1} 5", root, table.Size(),
+        MaxScore)
+  }
+}
+
+func TestSolvedMove_WinsFastLosesSlowly(t *testing.T) {
+  game := makeSolverGame()
+  for _, minimize := range []bool{false, true} {
+    table := Solve[MiniMaxMove](game, minimize)
+
+    move, ok := SolvedMove[MiniMaxMove](table, game, minimize)
+
+    want := "b"
+    if minimize {
+      want = "a"
+    }
+    if !ok || move != want {
+      t.Errorf("minimize: %v\ngot move: %v\nwant: %v", minimize, move, want)
+    }
+  }
+}
+
+func TestSolutionTable_SaveAndLoad(t *testing.T) {
+  table := Solve[MiniMaxMove](makeSolverGame(), false)
+  var buffer bytes.Buffer
+
+  if err := table.Save(&buffer); err != nil {
+    t.Fatal(err)
+  }
+  loaded, err := LoadSolutionTable(&buffer)
+
+  if err != nil || !reflect.DeepEqual(loaded, table) {
+    t.Errorf("got table: %v error: %v\nwant: %v", loaded, err, table)
+  }
+}
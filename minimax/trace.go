@@ -0,0 +1,148 @@
+package minimax
+
+import (
+  "encoding/json"
+  "fmt"
+  "io"
+  "strconv"
+  "strings"
+)
+
+// A position visited by a traced search.
+type TraceNode struct {
+  // The move that led here, printed with fmt. Empty at the root.
+  Move string `json:"move,omitempty"`
+  Minimize bool `json:"minimize"`
+  // How many moves ahead it was searched, and with what window.
+  Depth int `json:"depth"`
+  Alpha Score `json:"alpha"`
+  Beta Score `json:"beta"`
+  // Exact if it lies strictly between Alpha and Beta, otherwise a bound.
+  Score Score `json:"score"`
+  // The score came from the transposition table.
+  TableHit bool `json:"tableHit,omitempty"`
+  // A null move showed the side to move does too well, so no moves were
+  // searched.
+  NullMovePruned bool `json:"nullMovePruned,omitempty"`
+  // The window closed before every move was searched.
+  Cutoff bool `json:"cutoff,omitempty"`
+  // In the order they were searched. A move searched more than once, e.g. by
+  // principal variation search, shows up once per search.
+  Children []*TraceNode `json:"children,omitempty"`
+}
+
+// Tracing is off by default. With a positive depth, each root search records
+// the positions it visits up to that many moves from the root. Quiescence and
+// null move searches, and root workers, aren't recorded. Traces grow quickly
+// with depth, so keep it small.
+func (state *State[M]) SetTraceDepth(depth int) {
+  state.traceDepth = depth
+}
+
+// Returns the tree recorded by the last root search, e.g. the last depth of
+// IterativeDeepening, or nil if there isn't one.
+func (state *State[M]) GetTrace() *TraceNode {
+  return state.traceRoot
+}
+
+func (state *State[M]) startTrace(depth int, alpha Score, beta Score) {
+  if state.traceDepth <= 0 {
+    state.traceRoot = nil
+    state.tracePath = nil
+    return
+  }
+  state.traceRoot = &TraceNode{
+    Minimize: state.minimizeStart,
+    Depth: depth,
+    Alpha: alpha,
+    Beta: beta,
+  }
+  state.tracePath = append(state.tracePath[:0], state.traceRoot)
+}
+
+// Returns the node for the position being searched at ply, or nil if it isn't
+// traced.
+func (state *State[M]) currentTrace(ply int) *TraceNode {
+  if ply != len(state.tracePath) - 1 {
+    return nil
+  }
+  return state.tracePath[ply]
+}
+
+// Starts tracing the position after move, which is searched at ply.
+func (state *State[M]) pushTrace(
+    ply int, move M, minimize bool, depth int, alpha Score, beta Score) {
+  parent := state.currentTrace(ply - 1)
+  if parent == nil || ply > state.traceDepth {
+    return
+  }
+  node := &TraceNode{
+    Move: fmt.Sprint(move),
+    Minimize: minimize,
+    Depth: depth,
+    Alpha: alpha,
+    Beta: beta,
+  }
+  parent.Children = append(parent.Children, node)
+  state.tracePath = append(state.tracePath, node)
+}
+
+func (state *State[M]) popTrace(ply int, score Score) {
+  if node := state.currentTrace(ply); node != nil {
+    node.Score = score
+    state.tracePath = state.tracePath[:ply]
+  }
+}
+
+// Writes the tree as indented JSON.
+func (node *TraceNode) WriteJSON(writer io.Writer) error {
+  encoder := json.NewEncoder(writer)
+  encoder.SetIndent("", "  ")
+  return encoder.Encode(node)
+}
+
+// Writes the tree as a Graphviz digraph, e.g. for dot -Tsvg. Maximizing
+// positions are boxes and minimizing ones ellipses. Table hits are grey and
+// cutoffs red.
+func (node *TraceNode) WriteDot(writer io.Writer) error {
+  builder := &strings.Builder{}
+  builder.WriteString("digraph trace {\n")
+  next := 0
+  node.writeDot(builder, &next)
+  builder.WriteString("}\n")
+  _, err := io.WriteString(writer, builder.String())
+  return err
+}
+
+// Writes node and its children, numbering them from next. Returns node's
+// number.
+func (node *TraceNode) writeDot(builder *strings.Builder, next *int) int {
+  id := *next
+  *next++
+  shape := "box"
+  if node.Minimize {
+    shape = "ellipse"
+  }
+  label := fmt.Sprintf(
+      "%v\ndepth %v [%v, %v]", ScoreString(node.Score), node.Depth,
+      ScoreString(node.Alpha), ScoreString(node.Beta))
+  if node.NullMovePruned {
+    label += "\nnull move"
+  }
+  attributes := ""
+  if node.TableHit {
+    attributes += " style=filled fillcolor=lightgrey"
+  }
+  if node.Cutoff {
+    attributes += " color=red"
+  }
+  builder.WriteString(fmt.Sprintf(
+      "  n%v [shape=%v label=%v%v]\n", id, shape, strconv.Quote(label),
+      attributes))
+  for _, child := range node.Children {
+    childId := child.writeDot(builder, next)
+    builder.WriteString(fmt.Sprintf(
+        "  n%v -> n%v [label=%v]\n", id, childId, strconv.Quote(child.Move)))
+  }
+  return id
+}
@@ -0,0 +1,139 @@
+package minimax
+
+import (
+  "bytes"
+  "encoding/json"
+  "fmt"
+  "reflect"
+  "strings"
+  "testing"
+)
+
+// Two moves, a and b, in every position until depth moves are made. Scores
+// positions by the moves that led to them.
+type pathGame struct {
+  moves []MiniMaxMove
+  depth int
+  scores map[string]Score
+}
+
+func (game *pathGame) GetAllMoves() []MiniMaxMove {
+  if len(game.moves) == game.depth {
+    return nil
+  }
+  return []MiniMaxMove{"a", "b"}
+}
+
+func (game *pathGame) GetScore() Score {
+  return game.scores[game.StringKey()]
+}
+
+func (game *pathGame) MakeMove(move MiniMaxMove) {
+  game.moves = append(game.moves, move)
+}
+
+func (game *pathGame) UndoMove() {
+  game.moves = game.moves[:len(game.moves) - 1]
+}
+
+func (game *pathGame) Clone() MiniMaxGame {
+  moves := append([]MiniMaxMove{}, game.moves...)
+  return &pathGame{moves, game.depth, game.scores}
+}
+
+func (game *pathGame) String() string {
+  return game.StringKey()
+}
+
+func (game *pathGame) StringKey() string {
+  return strings.Trim(fmt.Sprint(game.moves), "[]")
+}
+
+// Three moves deep, with every leaf scored differently.
+func makeScoredPathGame() *pathGame {
+  return &pathGame{
+      nil, 3, map[string]Score{
+          "a a a": 3, "a a b": 7, "a b a": 5, "a b b": 1,
+          "b a a": 6, "b a b": 2, "b b a": 8, "b b b": 4}}
+}
+
+func makeTracedState(traceDepth int) *MiniMaxState {
+  game := &pathGame{
+      nil, 2, map[string]Score{"a a": 3, "a b": 5, "b a": 2, "b b": 9}}
+  state := MakeState(game, false, 2)
+  state.SetTraceDepth(traceDepth)
+  state.GetMove()
+  return state
+}
+
+func TestTrace(t *testing.T) {
+  trace := makeTracedState(2).GetTrace()
+
+  want := &TraceNode{
+      "", false, 2, MinScore, MaxScore, 3, false, false, false,
+      []*TraceNode{
+          {"a", true, 1, MinScore, MaxScore, 3, false, false, false,
+              []*TraceNode{
+                  {"a", false, 0, MinScore, MaxScore, 3, false, false, false,
+                      nil},
+                  {"b", false, 0, MinScore, 3, 5, false, false, false, nil}}},
+          // b a is already worse for max than a, so b b isn't searched.
+          {"b", true, 1, 3, MaxScore, 2, false, false, true,
+              []*TraceNode{
+                  {"a", false, 0, 3, MaxScore, 2, false, false, false,
+                      nil}}}}}
+  if !reflect.DeepEqual(trace, want) {
+    var got, wanted bytes.Buffer
+    trace.WriteJSON(&got)
+    want.WriteJSON(&wanted)
+    t.Errorf("got trace: %v\nwant: %v", got.String(), wanted.String())
+  }
+}
+
+func TestTrace_Depth(t *testing.T) {
+  trace := makeTracedState(1).GetTrace()
+
+  if len(trace.Children) != 2 || trace.Children[0].Children != nil {
+    t.Errorf("got children: %v\nwant two without children", trace.Children)
+  }
+}
+
+func TestTrace_Off(t *testing.T) {
+  if trace := makeTracedState(0).GetTrace(); trace != nil {
+    t.Errorf("got trace: %v\nwant nil", trace)
+  }
+}
+
+func TestTraceNode_WriteJSON(t *testing.T) {
+  trace := makeTracedState(2).GetTrace()
+  var buffer bytes.Buffer
+
+  if err := trace.WriteJSON(&buffer); err != nil {
+    t.Fatal(err)
+  }
+
+  got := &TraceNode{}
+  if err := json.Unmarshal(buffer.Bytes(), got); err != nil {
+    t.Fatal(err)
+  }
+  if !reflect.DeepEqual(got, trace) {
+    t.Errorf("got trace: %v\nwant: %v", got, trace)
+  }
+}
+
+func TestTraceNode_WriteDot(t *testing.T) {
+  trace := makeTracedState(2).GetTrace()
+  var buffer bytes.Buffer
+
+  if err := trace.WriteDot(&buffer); err != nil {
+    t.Fatal(err)
+  }
+
+  dot := buffer.String()
+  if !strings.HasPrefix(dot, "digraph trace {\n") ||
+      strings.Count(dot, "->") != 5 ||
+      !strings.Contains(dot, `n0 -> n4 [label="b"]`) ||
+      !strings.Contains(dot, "color=red") {
+    t.Errorf("got dot:\n%v", dot)
+  }
+}
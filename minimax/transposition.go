@@ -0,0 +1,188 @@
+package minimax
+
+import (
+  "hash/fnv"
+  "sync"
+)
+
+// About 2^18 entries of 88 bytes each, plus the keys of games that don't
+// implement MiniMaxHasher.
+const kDefaultTableEntries = 1 << 18
+
+// Buckets are split between this many locks so that goroutines sharing a
+// table rarely wait on each other.
+const kTableShards = 64
+
+// Says how a stored score relates to the real score of a position.
+type Bound int
+
+const (
+  ExactBound Bound = iota
+  // The real score is at least the stored score.
+  LowerBound = iota
+  // The real score is at most the stored score.
+  UpperBound = iota
+)
+
+type tableEntry struct {
+  hash uint64
+  // Empty if the game implements MiniMaxHasher.
+  key string
+  minimize bool
+  // How many moves ahead the score was searched.
+  depth int
+  bound Bound
+  score Score
+  // The key of the best move, if there is one.
+  bestMove uint64
+  hasMove bool
+  // The search that stored the entry. Entries from old searches are replaced
+  // first.
+  generation int
+  used bool
+}
+
+// Caches search results by position and side to move. Entries live in
+// buckets of two: the first keeps the deepest result and the second whatever
+// came last. It holds at most the number of entries it was made with, and
+// persists across searches. It's safe to share between goroutines searching
+// at the same time, but not while one of them starts a new search.
+type TranspositionTable struct {
+  entries []tableEntry
+  generation int
+  shards [kTableShards]sync.Mutex
+}
+
+func MakeTranspositionTable(maxEntries int) *TranspositionTable {
+  if maxEntries < 2 {
+    maxEntries = 2
+  }
+  return &TranspositionTable{entries: make([]tableEntry, maxEntries &^ 1)}
+}
+
+func (table *TranspositionTable) Clear() {
+  table.lockAll()
+  defer table.unlockAll()
+  for i := range table.entries {
+    table.entries[i] = tableEntry{}
+  }
+  table.generation = 0
+}
+
+// Returns the number of entries in use.
+func (table *TranspositionTable) Size() int {
+  table.lockAll()
+  defer table.unlockAll()
+  size := 0
+  for i := range table.entries {
+    if table.entries[i].used {
+      size++
+    }
+  }
+  return size
+}
+
+func (table *TranspositionTable) lockAll() {
+  for i := range table.shards {
+    table.shards[i].Lock()
+  }
+}
+
+func (table *TranspositionTable) unlockAll() {
+  for i := range table.shards {
+    table.shards[i].Unlock()
+  }
+}
+
+func (table *TranspositionTable) shard(bucket int) *sync.Mutex {
+  return &table.shards[bucket / 2 % kTableShards]
+}
+
+// Called at the start of each search so that older entries age.
+func (table *TranspositionTable) newSearch() {
+  table.generation++
+}
+
+func hashString(key string) uint64 {
+  hash := fnv.New64a()
+  hash.Write([]byte(key))
+  return hash.Sum64()
+}
+
+func (table *TranspositionTable) bucket(hash uint64, minimize bool) int {
+  if minimize {
+    hash = ^hash
+  }
+  return int(hash % uint64(len(table.entries) / 2)) * 2
+}
+
+func (entry *tableEntry) matches(
+    hash uint64, key string, minimize bool) bool {
+  return entry.used && entry.hash == hash && entry.key == key &&
+      entry.minimize == minimize
+}
+
+func (table *TranspositionTable) lookup(
+    hash uint64, key string, minimize bool) (tableEntry, bool) {
+  i := table.bucket(hash, minimize)
+  shard := table.shard(i)
+  shard.Lock()
+  defer shard.Unlock()
+  for _, entry := range table.entries[i:i + 2] {
+    if entry.matches(hash, key, minimize) {
+      return entry, true
+    }
+  }
+  return tableEntry{}, false
+}
+
+func (table *TranspositionTable) store(
+    hash uint64, key string, minimize bool, depth int, bound Bound,
+    score Score, bestMove uint64, hasMove bool) {
+  entry := tableEntry{
+    hash, key, minimize, depth, bound, score, bestMove, hasMove,
+    table.generation, true}
+  i := table.bucket(hash, minimize)
+  shard := table.shard(i)
+  shard.Lock()
+  defer shard.Unlock()
+  deep, recent := &table.entries[i], &table.entries[i + 1]
+  if recent.matches(hash, key, minimize) {
+    // Don't keep two copies of the same position.
+    *recent = tableEntry{}
+  }
+  if !deep.used || deep.generation != table.generation ||
+      deep.matches(hash, key, minimize) || depth >= deep.depth {
+    if deep.used && !deep.matches(hash, key, minimize) {
+      // Demote rather than drop what was there.
+      *recent = *deep
+    }
+    *deep = entry
+  } else {
+    *recent = entry
+  }
+}
+
+// Returns true if the entry settles the score for a search with the given
+// depth and window.
+func (entry *tableEntry) cutsOff(depth int, alpha Score, beta Score) bool {
+  // A deeper search settles a shallower one just as well.
+  if entry.depth < depth {
+    return false
+  }
+  switch entry.bound {
+    case LowerBound: return entry.score >= beta
+    case UpperBound: return entry.score <= alpha
+  }
+  return true
+}
+
+func boundFor(score Score, alpha Score, beta Score) Bound {
+  if isExact(score, alpha, beta) {
+    return ExactBound
+  }
+  if score <= alpha {
+    return UpperBound
+  }
+  return LowerBound
+}
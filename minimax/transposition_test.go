@@ -0,0 +1,76 @@
+package minimax
+
+import "testing"
+
+func TestTranspositionTable_Lookup(t *testing.T) {
+  table := MakeTranspositionTable(16)
+  move := hashString("move")
+  table.store(hashString("abc"), "abc", false, 3, ExactBound, 5, move, true)
+
+  if entry, ok := table.lookup(hashString("abc"), "abc", true); ok {
+    t.Errorf("got entry for other side to move: %v", entry)
+  }
+  entry, ok := table.lookup(hashString("abc"), "abc", false)
+  if !ok || entry.depth != 3 || entry.score != 5 ||
+      entry.bestMove != move || !entry.hasMove {
+    t.Errorf("got entry: %v\nwant depth 3, score 5, move", entry)
+  }
+}
+
+func TestTranspositionTable_KeepsDeepest(t *testing.T) {
+  // One bucket, so every key collides.
+  table := MakeTranspositionTable(2)
+  table.newSearch()
+  table.store(hashString("deep"), "deep", false, 5, ExactBound, 1, 0, false)
+  table.store(
+      hashString("shallow1"), "shallow1", false, 1, ExactBound, 2, 0, false)
+  table.store(
+      hashString("shallow2"), "shallow2", false, 1, ExactBound, 3, 0, false)
+
+  if _, ok := table.lookup(hashString("deep"), "deep", false); !ok {
+    t.Errorf("deepest entry was replaced")
+  }
+  if _, ok := table.lookup(hashString("shallow1"), "shallow1", false); ok {
+    t.Errorf("older shallow entry was kept")
+  }
+  if _, ok := table.lookup(hashString("shallow2"), "shallow2", false); !ok {
+    t.Errorf("newest entry was dropped")
+  }
+  if size := table.Size(); size != 2 {
+    t.Errorf("got size: %v\nwant: 2", size)
+  }
+}
+
+func TestTranspositionTable_ReplacesOldSearches(t *testing.T) {
+  table := MakeTranspositionTable(2)
+  table.newSearch()
+  table.store(hashString("old"), "old", false, 5, ExactBound, 1, 0, false)
+  table.newSearch()
+  table.store(hashString("new"), "new", false, 1, ExactBound, 2, 0, false)
+
+  if _, ok := table.lookup(hashString("new"), "new", false); !ok {
+    t.Errorf("new entry was dropped")
+  }
+}
+
+func TestTableEntry_CutsOff(t *testing.T) {
+  lower := &tableEntry{depth: 2, bound: LowerBound, score: 10}
+  upper := &tableEntry{depth: 2, bound: UpperBound, score: 10}
+  exact := &tableEntry{depth: 2, bound: ExactBound, score: 10}
+
+  if !lower.cutsOff(2, 0, 10) || lower.cutsOff(2, 0, 11) {
+    t.Errorf("lower bound cut off wrong")
+  }
+  if !upper.cutsOff(2, 10, 20) || upper.cutsOff(2, 9, 20) {
+    t.Errorf("upper bound cut off wrong")
+  }
+  if !exact.cutsOff(2, MinScore, MaxScore) {
+    t.Errorf("exact score didn't cut off")
+  }
+  if exact.cutsOff(3, MinScore, MaxScore) {
+    t.Errorf("shallower score cut off a deeper search")
+  }
+  if !exact.cutsOff(1, MinScore, MaxScore) {
+    t.Errorf("deeper score didn't cut off a shallower search")
+  }
+}
@@ -0,0 +1,68 @@
+package minimax
+
+// Principal variation search is off by default. With it on, moves after the
+// first are searched with a null window that only tells whether they beat the
+// best so far, and searched again with the full window if they do. Works best
+// with good move ordering.
+func (state *State[M]) SetPrincipalVariationSearch(enabled bool) {
+  state.principalVariationSearch = enabled
+}
+
+// Aspiration windows are off by default. With a positive width, each depth of
+// IterativeDeepening and Search starts with a window that wide on either side
+// of the previous depth's score, and widens it whenever the score falls
+// outside. Root workers and random move picking always search the full
+// window.
+func (state *State[M]) SetAspirationWindow(width Score) {
+  state.aspirationWindow = width
+}
+
+// Adds without overflowing, so that windows around MaxScore and MinScore stay
+// in range.
+func addScores(a Score, b Score) Score {
+  if b > 0 && a > MaxScore - b {
+    return MaxScore
+  }
+  if b < 0 && a < MinScore - b {
+    return MinScore
+  }
+  return a + b
+}
+
+// Searches move with a window of width one at the edge the side to move has
+// to beat. Returns the score and true if it doesn't beat it. Otherwise the
+// score is only a bound.
+func (state *State[M]) tryNullWindow(
+    minimize bool, ply int, depth int, move M, alpha Score,
+    beta Score) (Score, bool) {
+  if minimize {
+    score := state.tryMove(minimize, ply, depth, move, beta - 1, beta)
+    return score, score >= beta
+  }
+  score := state.tryMove(minimize, ply, depth, move, alpha, alpha + 1)
+  return score, score <= alpha
+}
+
+// Searches the root depth moves ahead, starting with a window around guess.
+func (state *State[M]) runAspiration(
+    depth int, guess Score) (*M, Score) {
+  width := state.aspirationWindow
+  if width <= 0 || !state.alphaBeta || IsMate(guess) || state.isRandom() {
+    return state.runRoot(depth, MinScore, MaxScore)
+  }
+  alpha, beta := addScores(guess, -width), addScores(guess, width)
+  for {
+    move, score := state.runRoot(depth, alpha, beta)
+    if state.aborted || isExact(score, alpha, beta) {
+      return move, score
+    }
+    // The score is a bound on the real one, so widen past it, further each
+    // time.
+    width = addScores(width, width)
+    if score <= alpha {
+      alpha = addScores(score, -width)
+    } else {
+      beta = addScores(score, width)
+    }
+  }
+}
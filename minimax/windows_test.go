@@ -0,0 +1,25 @@
+package minimax
+
+import "testing"
+
+func TestAddScores(t *testing.T) {
+  for _, test := range []struct {
+    a Score
+    b Score
+    want Score
+  }{
+    {1, 2, 3},
+    {-1, -2, -3},
+    {MaxScore, 1, MaxScore},
+    {MaxScore - 1, 5, MaxScore},
+    {MinScore, -1, MinScore},
+    {MinScore + 1, -5, MinScore},
+    {MaxScore, MinScore, -1},
+  } {
+    if got := addScores(test.a, test.b); got != test.want {
+      t.Errorf(
+          "addScores(%v, %v)\ngot: %v\nwant: %v", test.a, test.b, got,
+          test.want)
+    }
+  }
+}
@@ -1,7 +1,12 @@
-module main
+module jsdu/tictactoe/main
 
-go 1.16
+go 1.18
 
 replace minimax => ../../minimax
 
-require minimax v0.0.0-00010101000000-000000000000 // indirect
+replace mcts => ../../mcts
+
+require (
+	mcts v0.0.0-00010101000000-000000000000
+	minimax v0.0.0-00010101000000-000000000000
+)
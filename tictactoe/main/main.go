@@ -1,8 +1,11 @@
 package main
 
 import (
+  "flag"
   "fmt"
+  "mcts"
   "minimax"
+  "os"
   "strings"
 )
 
@@ -47,6 +50,10 @@ type Move struct {
   color Color
 }
 
+func (move *Move) MoveKey() uint64 {
+  return uint64(move.coord.row * 3 + move.coord.col + 9 * int(move.color))
+}
+
 func (move *Move) String() string {
   return fmt.Sprint(move.coord, ", ", move.color)
 }
@@ -123,6 +130,21 @@ func (board *Board) StringKey() string {
   return builder.String()
 }
 
+// Reads the board as a base 3 number, so every board hashes differently.
+func (board *Board) Hash() uint64 {
+  hash := uint64(0)
+  for row := 0; row < 3; row++ {
+    for col := 0; col < 3; col++ {
+      hash *= 3
+      switch board.Get(row, col) {
+        case 'x': hash += 1
+        case 'o': hash += 2
+      }
+    }
+  }
+  return hash
+}
+
 type State int
 
 const (
@@ -150,17 +172,28 @@ type Game struct {
   board *Board
   turn Color
   cachedState State
-  moveHistory []*Move
+  moveHistory []Move
 }
 
 func MakeGame() *Game {
   game := &Game{}
   game.board = MakeBoard()
   game.turn = kX
-  game.moveHistory = make([]*Move, 0, 9)
+  game.moveHistory = make([]Move, 0, 9)
   return game
 }
 
+func (game *Game) Clone() *Game {
+  clone := &Game{}
+  board := *game.board
+  clone.board = &board
+  clone.turn = game.turn
+  clone.cachedState = game.cachedState
+  clone.moveHistory = append(
+      make([]Move, 0, cap(game.moveHistory)), game.moveHistory...)
+  return clone
+}
+
 func (game *Game) GetBoard() *Board {
   return game.board
 }
@@ -171,10 +204,6 @@ func (game *Game) GetTurn() Color {
 
 func (game *Game) String() string {
   return game.board.String()
-  builder := &strings.Builder{}
-  builder.WriteString(game.GetBoard().String())
-  builder.WriteByte('\n')
-  return builder.String()
 }
 
 func outOfRange(val int) bool {
@@ -190,7 +219,7 @@ func isIllegal(game *Game, move* Move) bool {
 
 func (game *Game) MakeMove(move* Move) error {
   if isIllegal(game, move) {
-    return &GameError{fmt.Sprint("Illegal move: ", move)}
+    return &GameError{"Illegal move: " + move.String()}
   }
   game.board.Set(move.coord.row, move.coord.col, MakePiece(move.color))
   if game.turn == kX {
@@ -198,7 +227,7 @@ func (game *Game) MakeMove(move* Move) error {
   } else {
     game.turn = kX
   }
-  game.moveHistory = append(game.moveHistory, move)
+  game.moveHistory = append(game.moveHistory, *move)
   return nil
 }
 
@@ -256,16 +285,21 @@ func (game *Game) GetState() State {
 
 type HumanPlayer struct {
   color Color
+  game *Game
 }
 
-func MakeHumanPlayer(color Color) *HumanPlayer {
-  return &HumanPlayer{color}
+func MakeHumanPlayer(color Color, game *Game) *HumanPlayer {
+  return &HumanPlayer{color, game}
 }
 
 func (player* HumanPlayer) GetMove() (*Move, error) {
-  fmt.Printf("%v's turn. Enter move: ", player.color.String())
+  fmt.Printf("%v's turn. Enter move (or h for hints): ", player.color.String())
   var line string
   fmt.Scanln(&line)
+  if line == "h" {
+    player.printHints()
+    return player.GetMove()
+  }
   if len(line) != 2 {
     return nil, &GameError{"Invalid move. Expected format <row><col>"}
   }
@@ -273,18 +307,105 @@ func (player* HumanPlayer) GetMove() (*Move, error) {
          nil
 }
 
+// Prints every move with how it scores for x.
+func (player *HumanPlayer) printHints() {
+  state := minimax.MakeGameState[Move](
+      &TypedAiGame{player.game}, player.color == kO, 9)
+  for _, ranked := range state.GetTopMoves(9) {
+    move := ranked.Move
+    fmt.Printf(
+        "%v%v: %v\n", move.coord.row, move.coord.col,
+        scoreString(ranked.Score))
+  }
+}
+
+func scoreString(score minimax.Score) string {
+  mateIn := minimax.MateIn(score)
+  switch {
+    case mateIn > 0: return fmt.Sprintf("x wins in %v", mateIn)
+    case mateIn < 0: return fmt.Sprintf("o wins in %v", -mateIn)
+    default: return "draw"
+  }
+}
+
 type AiPlayer struct {
-  state *minimax.MiniMaxState
+  state *minimax.State[Move]
 }
 
 func MakeAiPlayer(color Color, game *Game) *AiPlayer {
-  return &AiPlayer{minimax.MakeState(&AiGame{game}, color == kO, 9)}
+  return &AiPlayer{
+      minimax.MakeGameState[Move](&TypedAiGame{game}, color == kO, 9)}
 }
 
 func (player *AiPlayer) GetMove() (*Move, error) {
+  move := player.state.GetMove()
+  return &move, nil
+}
+
+// Plays with Monte Carlo tree search instead of minimax.
+type MctsPlayer struct {
+  state *mcts.MctsState
+}
+
+func MakeMctsPlayer(color Color, game *Game, iterations int) *MctsPlayer {
+  state := mcts.MakeState(&AiGame{game}, color == kO)
+  state.SetIterations(iterations)
+  return &MctsPlayer{state}
+}
+
+func (player *MctsPlayer) GetMove() (*Move, error) {
   return player.state.GetMove().(*Move), nil
 }
 
+// Plays perfectly by looking moves up in a solved table.
+type TablePlayer struct {
+  color Color
+  game *Game
+  table *minimax.SolutionTable
+}
+
+func MakeTablePlayer(
+    color Color, game *Game, table *minimax.SolutionTable) *TablePlayer {
+  return &TablePlayer{color, game, table}
+}
+
+func (player *TablePlayer) GetMove() (*Move, error) {
+  move, ok := minimax.SolvedMove[Move](
+      player.table, &TypedAiGame{player.game}, player.color == kO)
+  if !ok {
+    return nil, &GameError{"Position isn't in the table"}
+  }
+  return &move, nil
+}
+
+// Solves every position reachable from the empty board.
+func SolveGame() *minimax.SolutionTable {
+  return minimax.Solve[Move](&TypedAiGame{MakeGame()}, false)
+}
+
+// Reads the table saved at path, or solves the game and saves it there if
+// there isn't one.
+func LoadOrSolve(path string) (*minimax.SolutionTable, error) {
+  file, err := os.Open(path)
+  if err == nil {
+    defer file.Close()
+    return minimax.LoadSolutionTable(file)
+  }
+  if !os.IsNotExist(err) {
+    return nil, err
+  }
+  table := SolveGame()
+  file, err = os.Create(path)
+  if err != nil {
+    return nil, err
+  }
+  if err := table.Save(file); err != nil {
+    file.Close()
+    return nil, err
+  }
+  return table, file.Close()
+}
+
 type AiGame struct {
   game *Game
 }
@@ -297,6 +418,14 @@ func (aiGame *AiGame) StringKey() string {
   return aiGame.game.GetBoard().StringKey()
 }
 
+func (aiGame *AiGame) Clone() minimax.MiniMaxGame {
+  return &AiGame{aiGame.game.Clone()}
+}
+
+func (aiGame *AiGame) Hash() uint64 {
+  return aiGame.game.GetBoard().Hash()
+}
+
 func (aiGame *AiGame) GetAllMoves() []minimax.MiniMaxMove {
   game := aiGame.game
   board := game.GetBoard()
@@ -330,6 +459,59 @@ func (aiGame *AiGame) UndoMove() {
   aiGame.game.UndoMove()
 }
 
+// Like AiGame, but searched with moves as values so they don't need boxing.
+type TypedAiGame struct {
+  game *Game
+}
+
+func (aiGame *TypedAiGame) String() string {
+  return aiGame.game.String()
+}
+
+func (aiGame *TypedAiGame) StringKey() string {
+  return aiGame.game.GetBoard().StringKey()
+}
+
+func (aiGame *TypedAiGame) Clone() minimax.Game[Move] {
+  return &TypedAiGame{aiGame.game.Clone()}
+}
+
+func (aiGame *TypedAiGame) Hash() uint64 {
+  return aiGame.game.GetBoard().Hash()
+}
+
+func (aiGame *TypedAiGame) HashMove(move Move) uint64 {
+  return move.MoveKey()
+}
+
+func (aiGame *TypedAiGame) GetAllMoves() []Move {
+  game := aiGame.game
+  board := game.GetBoard()
+  moves := make([]Move, 0, 9)
+  for row := 0; row < 3; row++ {
+    for col := 0; col < 3; col++ {
+      if board.Get(row, col) == ' ' {
+        moves = append(moves, Move{Coord{row, col}, game.GetTurn()})
+      }
+    }
+  }
+  return moves
+}
+
+func (aiGame *TypedAiGame) GetScore() minimax.Score {
+  return (&AiGame{aiGame.game}).GetScore()
+}
+
+func (aiGame *TypedAiGame) MakeMove(move Move) {
+  if ok := aiGame.game.MakeMove(&move); ok != nil {
+    panic(ok.Error())
+  }
+}
+
+func (aiGame *TypedAiGame) UndoMove() {
+  aiGame.game.UndoMove()
+}
+
 type PlayerManager struct {
   xPlayer Player
   oPlayer Player
@@ -349,9 +531,23 @@ func (manager *PlayerManager) GetCurrentPlayer() Player {
 }
 
 func main() {
+  tablePath := flag.String(
+      "table", "", "play from the solved game saved here, solving it first "+
+      "if needed")
+  flag.Parse()
   game := MakeGame()
   manager := MakePlayerManager(
       MakeAiPlayer(kX, game), MakeAiPlayer(kO, game), game)
+  if *tablePath != "" {
+    table, err := LoadOrSolve(*tablePath)
+    if err != nil {
+      fmt.Println(err)
+      return
+    }
+    manager = MakePlayerManager(
+        MakeTablePlayer(kX, game, table), MakeTablePlayer(kO, game, table),
+        game)
+  }
   state := kNotOver
   for ; state == kNotOver; state = game.GetState() {
     fmt.Print(game)
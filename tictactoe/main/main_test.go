@@ -0,0 +1,391 @@
+package main
+
+import (
+  "context"
+  "fmt"
+  "minimax"
+  "path/filepath"
+  "reflect"
+  "testing"
+)
+
+func makeMoves(t *testing.T, game *Game, coords []Coord) {
+  for _, coord := range coords {
+    if err := game.MakeMove(&Move{coord, game.GetTurn()}); err != nil {
+      t.Fatal(err)
+    }
+  }
+}
+
+func checkAlphaBeta(t *testing.T, coords []Coord) {
+  game := MakeGame()
+  makeMoves(t, game, coords)
+  aiGame := &AiGame{game}
+  minimize := game.GetTurn() == kO
+  plain := minimax.MakeState(aiGame, minimize, 9)
+  plain.SetAlphaBeta(false)
+  pruned := minimax.MakeState(aiGame, minimize, 9)
+
+  want := plain.GetMove().(*Move)
+  got := pruned.GetMove().(*Move)
+
+  if *got != *want {
+    t.Errorf("game:\n%v\ngot move: %v\nwant: %v", game, got, want)
+  }
+  if pruned.GetNodeCount() >= plain.GetNodeCount() {
+    t.Errorf(
+        "game:\n%v\ngot nodes: %v\nwant fewer than: %v", game,
+        pruned.GetNodeCount(), plain.GetNodeCount())
+  }
+}
+
+func TestAlphaBeta_Empty(t *testing.T) {
+  checkAlphaBeta(t, []Coord{})
+}
+
+func TestAlphaBeta_Corner(t *testing.T) {
+  checkAlphaBeta(t, []Coord{{0, 0}})
+}
+
+func TestAlphaBeta_MustBlock(t *testing.T) {
+  checkAlphaBeta(t, []Coord{{0, 0}, {1, 1}, {0, 1}})
+}
+
+func TestAlphaBeta_CanWin(t *testing.T) {
+  checkAlphaBeta(t, []Coord{{0, 0}, {1, 1}, {0, 1}, {2, 2}})
+}
+
+// The first search already saw every position after the next move, with more
+// depth to go than the second search needs, so only the root's moves are
+// made.
+func TestTranspositionTable_PersistsBetweenMoves(t *testing.T) {
+  game := MakeGame()
+  first := minimax.MakeState(&AiGame{game}, false, 9)
+  game.MakeMove(first.GetMove().(*Move))
+  game.MakeMove((&AiGame{game}).GetAllMoves()[0].(*Move))
+
+  state := minimax.MakeState(&AiGame{game}, false, 5)
+  state.SetTable(first.GetTable())
+  want := state.GetMove().(*Move)
+  kept := state.GetNodeCount()
+  state.SetTable(minimax.MakeTranspositionTable(1 << 16))
+  got := state.GetMove().(*Move)
+
+  moves := len((&AiGame{game}).GetAllMoves())
+  if kept > moves {
+    t.Errorf("got nodes: %v\nwant at most: %v", kept, moves)
+  }
+  if *got != *want {
+    t.Errorf("got move: %v\nwant: %v", got, want)
+  }
+}
+
+func TestHash(t *testing.T) {
+  game := MakeGame()
+  seen := make(map[uint64]string)
+  var visit func()
+  visit = func() {
+    board := game.GetBoard()
+    if key, ok := seen[board.Hash()]; ok {
+      if key != board.StringKey() {
+        t.Fatalf("boards %q and %q have the same hash", key, board.StringKey())
+      }
+      return
+    }
+    seen[board.Hash()] = board.StringKey()
+    if game.GetState() != kNotOver {
+      return
+    }
+    for _, move := range (&AiGame{game}).GetAllMoves() {
+      game.MakeMove(move.(*Move))
+      visit()
+      game.UndoMove()
+    }
+  }
+
+  visit()
+
+  if len(seen) != 5478 {
+    t.Errorf("got boards: %v\nwant: 5478", len(seen))
+  }
+}
+
+func TestRootWorkers_MatchesSequential(t *testing.T) {
+  for _, coords := range [][]Coord{{}, {{0, 0}}, {{1, 1}, {0, 0}}} {
+    game := MakeGame()
+    makeMoves(t, game, coords)
+    minimize := game.GetTurn() == kO
+    sequential := minimax.MakeState(&AiGame{game}, minimize, 9)
+    parallel := minimax.MakeState(&AiGame{game}, minimize, 9)
+    parallel.SetRootWorkers(4)
+
+    want := sequential.GetMove().(*Move)
+    got := parallel.GetMove().(*Move)
+
+    if *got != *want {
+      t.Errorf("game:\n%v\ngot move: %v\nwant: %v", game, got, want)
+    }
+  }
+}
+
+func TestGetTopMoves(t *testing.T) {
+  for _, coords := range [][]Coord{
+      {}, {{0, 0}}, {{0, 0}, {1, 1}, {0, 1}}, {{1, 1}, {0, 0}, {2, 2}}} {
+    game := MakeGame()
+    makeMoves(t, game, coords)
+    minimize := game.GetTurn() == kO
+    plain := minimax.MakeState(&AiGame{game}, minimize, 9)
+    plain.SetAlphaBeta(false)
+    pruned := minimax.MakeState(&AiGame{game}, minimize, 9)
+
+    want := plain.GetTopMoves(9)[:3]
+    got := pruned.GetTopMoves(3)
+
+    for i := range want {
+      if *got[i].Move.(*Move) != *want[i].Move.(*Move) ||
+          got[i].Score != want[i].Score {
+        t.Errorf(
+            "game:\n%v\ngot move %v: %v %v\nwant: %v %v", game, i,
+            got[i].Move, got[i].Score, want[i].Move, want[i].Score)
+      }
+    }
+  }
+}
+
+// Wins and losses score MaxScore and MinScore, so windows around them have
+// to stay in range.
+func TestAspirationWindow_Wins(t *testing.T) {
+  for _, coords := range [][]Coord{
+      {}, {{0, 0}, {1, 1}, {0, 1}}, {{0, 0}, {1, 1}, {0, 1}, {2, 2}}} {
+    game := MakeGame()
+    makeMoves(t, game, coords)
+    minimize := game.GetTurn() == kO
+    full := minimax.MakeState(&AiGame{game}, minimize, 9)
+    aspiration := minimax.MakeState(&AiGame{game}, minimize, 9)
+    aspiration.SetAspirationWindow(1)
+    aspiration.SetPrincipalVariationSearch(true)
+
+    want := full.IterativeDeepening(0, 0).(*Move)
+    got := aspiration.IterativeDeepening(0, 0).(*Move)
+
+    if *got != *want {
+      t.Errorf("game:\n%v\ngot move: %v\nwant: %v", game, got, want)
+    }
+  }
+}
+
+func TestGetMove_PrefersFastestWin(t *testing.T) {
+  game := MakeGame()
+  // x can win now at 02, or in two moves with 10.
+  makeMoves(t, game, []Coord{{0, 0}, {1, 1}, {0, 1}, {2, 2}})
+  state := minimax.MakeState(&AiGame{game}, false, 9)
+
+  result := state.Search(context.Background())
+
+  if got := *result.Move.(*Move); got != (Move{Coord{0, 2}, kX}) {
+    t.Errorf("game:\n%v\ngot move: %v\nwant: 02", game, got)
+  }
+  if got := minimax.MateIn(result.Score); got != 1 {
+    t.Errorf("game:\n%v\ngot mate in: %v\nwant: 1", game, got)
+  }
+}
+
+func TestSearch_ForcedLoss(t *testing.T) {
+  game := MakeGame()
+  // o can't block all of 10, 11 and 21.
+  makeMoves(t, game, []Coord{{0, 0}, {0, 1}, {2, 2}, {0, 2}, {2, 0}})
+  state := minimax.MakeState(&AiGame{game}, true, 9)
+
+  result := state.Search(context.Background())
+
+  if got := minimax.MateIn(result.Score); got != 1 {
+    t.Errorf(
+        "game:\n%v\ngot result: %v\ngot mate in: %v\nwant: 1", game, result,
+        got)
+  }
+}
+
+func playGame(t *testing.T, game *Game, xPlayer Player, oPlayer Player) {
+  manager := MakePlayerManager(xPlayer, oPlayer, game)
+  for game.GetState() == kNotOver {
+    move, err := manager.GetCurrentPlayer().GetMove()
+    if err != nil {
+      t.Fatal(err)
+    }
+    if err := game.MakeMove(move); err != nil {
+      t.Fatalf("game:\n%v\nmove: %v\n%v", game, move, err)
+    }
+  }
+}
+
+func TestMctsPlayer_DrawsAgainstMinimax(t *testing.T) {
+  for _, mctsColor := range []Color{kX, kO} {
+    game := MakeGame()
+    xPlayer := Player(MakeAiPlayer(kX, game))
+    oPlayer := Player(MakeAiPlayer(kO, game))
+    if mctsColor == kX {
+      xPlayer = MakeMctsPlayer(kX, game, 5000)
+    } else {
+      oPlayer = MakeMctsPlayer(kO, game, 5000)
+    }
+
+    playGame(t, game, xPlayer, oPlayer)
+
+    if got := game.GetState(); got != kDraw {
+      t.Errorf("game:\n%v\ngot state: %v\nwant draw", game, got)
+    }
+  }
+}
+
+func TestMctsPlayer_TakesWin(t *testing.T) {
+  game := MakeGame()
+  makeMoves(t, game, []Coord{{0, 0}, {1, 1}, {0, 1}, {2, 2}})
+  player := MakeMctsPlayer(kX, game, 2000)
+
+  move, _ := player.GetMove()
+
+  if *move != (Move{Coord{0, 2}, kX}) {
+    t.Errorf("game:\n%v\ngot move: %v\nwant: 02", game, move)
+  }
+}
+
+// Returns the moves of a game between two AI players breaking ties with
+// seed.
+func playSeededGame(t *testing.T, seed int64) string {
+  game := MakeGame()
+  xPlayer := MakeAiPlayer(kX, game)
+  xPlayer.state.SetRandomSeed(seed)
+  oPlayer := MakeAiPlayer(kO, game)
+  oPlayer.state.SetRandomSeed(seed + 1)
+
+  playGame(t, game, xPlayer, oPlayer)
+
+  if got := game.GetState(); got != kDraw {
+    t.Errorf("game:\n%v\ngot state: %v\nwant draw", game, got)
+  }
+  return fmt.Sprint(game.moveHistory)
+}
+
+func TestRandomSeed_VariesAndRepeats(t *testing.T) {
+  games := make(map[string]bool)
+  for seed := int64(0); seed < 10; seed += 2 {
+    game := playSeededGame(t, seed)
+    if again := playSeededGame(t, seed); again != game {
+      t.Errorf("seed: %v\ngot game: %v\nthen: %v", seed, game, again)
+    }
+    games[game] = true
+  }
+
+  if len(games) < 2 {
+    t.Errorf("got games: %v\nwant different ones", games)
+  }
+}
+
+func TestSolveGame(t *testing.T) {
+  table := SolveGame()
+
+  // Including the empty board.
+  if size := table.Size(); size != 5478 {
+    t.Errorf("got positions: %v\nwant: 5478", size)
+  }
+  root, _ := table.Get(MakeGame().GetBoard().StringKey(), false)
+  if root.Score != 0 || root.Distance != 9 {
+    t.Errorf("got solution: %v\nwant a draw in 9", root)
+  }
+}
+
+func TestLoadOrSolve(t *testing.T) {
+  path := filepath.Join(t.TempDir(), "tictactoe.gob")
+  solved, err := LoadOrSolve(path)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  loaded, err := LoadOrSolve(path)
+
+  if err != nil || !reflect.DeepEqual(loaded, solved) {
+    t.Errorf("got table: %v error: %v\nwant the solved one", loaded, err)
+  }
+}
+
+func TestTablePlayer(t *testing.T) {
+  table := SolveGame()
+  for _, tableColor := range []Color{kX, kO} {
+    game := MakeGame()
+    xPlayer := Player(MakeAiPlayer(kX, game))
+    oPlayer := Player(MakeAiPlayer(kO, game))
+    if tableColor == kX {
+      xPlayer = MakeTablePlayer(kX, game, table)
+    } else {
+      oPlayer = MakeTablePlayer(kO, game, table)
+    }
+
+    playGame(t, game, xPlayer, oPlayer)
+
+    if got := game.GetState(); got != kDraw {
+      t.Errorf("game:\n%v\ngot state: %v\nwant draw", game, got)
+    }
+  }
+}
+
+func TestTablePlayer_WinsFastest(t *testing.T) {
+  game := MakeGame()
+  // x can win at once with 02, or later in other ways.
+  makeMoves(t, game, []Coord{{0, 0}, {1, 1}, {0, 1}, {2, 2}})
+
+  move, err := MakeTablePlayer(kX, game, SolveGame()).GetMove()
+
+  if err != nil || *move != (Move{Coord{0, 2}, kX}) {
+    t.Errorf("game:\n%v\ngot move: %v %v\nwant: 02", game, move, err)
+  }
+}
+
+func TestProveWin(t *testing.T) {
+  game := MakeGame()
+  // o's reply next to x's corner loses.
+  makeMoves(t, game, []Coord{{0, 0}, {0, 1}})
+
+  proof := minimax.ProveWin[Move](&TypedAiGame{game}, false, 0)
+
+  if proof.Status != minimax.Proven {
+    t.Fatalf("game:\n%v\ngot status: %v\nwant proven", game, proof.Status)
+  }
+  game.MakeMove(&proof.Move)
+  solution, _ := SolveGame().Get(game.GetBoard().StringKey(), true)
+  if solution.Score != minimax.MaxScore {
+    t.Errorf("game:\n%v\ngot solution: %v\nwant x wins", game, solution)
+  }
+}
+
+func TestProveWin_EmptyBoard(t *testing.T) {
+  for _, minimize := range []bool{false, true} {
+    game := MakeGame()
+    if minimize {
+      makeMoves(t, game, []Coord{{1, 1}})
+    }
+
+    proof := minimax.ProveWin[Move](&TypedAiGame{game}, minimize, 0)
+
+    if proof.Status != minimax.Disproven {
+      t.Errorf(
+          "game:\n%v\ngot status: %v\nwant disproven", game, proof.Status)
+    }
+  }
+}
+
+func benchmarkGetMove[M any](b *testing.B, state *minimax.State[M]) {
+  b.ReportAllocs()
+  for i := 0; i < b.N; i++ {
+    state.GetTable().Clear()
+    state.GetMove()
+  }
+}
+
+func BenchmarkGetMove_Interface(b *testing.B) {
+  benchmarkGetMove(b, minimax.MakeState(&AiGame{MakeGame()}, false, 9))
+}
+
+func BenchmarkGetMove_Typed(b *testing.B) {
+  benchmarkGetMove(
+      b, minimax.MakeGameState[Move](&TypedAiGame{MakeGame()}, false, 9))
+}